@@ -0,0 +1,16 @@
+package milkrandom
+
+import "math"
+
+// AcceptWorse implements the Metropolis acceptance criterion used in simulated annealing.
+// It always accepts when deltaE <= 0 and otherwise accepts with probability
+// exp(-deltaE/temperature). It panics if temperature <= 0.
+func AcceptWorse(src Source, deltaE, temperature float64) bool {
+	if !(temperature > 0) {
+		panic("milkrandom: argument temperature to AcceptWorse is <= 0")
+	}
+	if deltaE <= 0 {
+		return true
+	}
+	return float64From(src) < math.Exp(-deltaE/temperature)
+}
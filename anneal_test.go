@@ -0,0 +1,36 @@
+package milkrandom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAcceptWorse(t *testing.T) {
+	src := newTestSource(1)
+	const n = 100000
+	for _, c := range []struct{ deltaE, temperature float64 }{{1, 1}, {0.5, 2}, {3, 1}, {10, 100}} {
+		accepted := 0
+		for i := 0; i < n; i++ {
+			if AcceptWorse(src, c.deltaE, c.temperature) {
+				accepted++
+			}
+		}
+		want := math.Exp(-c.deltaE / c.temperature)
+		if got := float64(accepted) / n; math.Abs(got-want) > 0.01 {
+			t.Errorf("AcceptWorse(%v, %v) accepted %v of the time, want %v", c.deltaE, c.temperature, got, want)
+		}
+	}
+	for _, deltaE := range []float64{0, -1} {
+		for i := 0; i < 1000; i++ {
+			if !AcceptWorse(src, deltaE, 0.01) {
+				t.Fatalf("AcceptWorse(%v, 0.01) rejected an improvement", deltaE)
+			}
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("AcceptWorse with temperature 0 did not panic")
+		}
+	}()
+	AcceptWorse(src, 1, 0)
+}
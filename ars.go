@@ -0,0 +1,223 @@
+package milkrandom
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// arsMaxPoints caps the number of abscissae the envelope of an ARS sampler grows to.
+const arsMaxPoints = 64
+
+// ARS samples from a univariate log-concave density using adaptive rejection sampling
+// (Gilks and Wild, 1992). It keeps a piecewise-exponential upper envelope built from
+// tangents of the log density and a lower squeezing function built from chords, and
+// refines both with every point at which the density has to be evaluated, so rejections
+// become rarer as sampling proceeds. Derivatives are estimated by central differences.
+//
+// An ARS is not safe for concurrent use, since sampling updates the envelope.
+type ARS struct {
+	logpdf       func(float64) float64
+	lower, upper float64
+
+	x, h, dh []float64 // sorted abscissae, log density and its derivative at each
+	z        []float64 // envelope breakpoints; segment i spans [z[i], z[i+1]]
+	cum      []float64 // cumulative envelope mass, scaled by exp(-offset)
+	offset   float64
+}
+
+// NewAdaptiveRejection creates a new ARS sampler for the density proportional to exp(logpdf(x))
+// on [lower, upper]. The bounds may be infinite, in which case the density must decay towards
+// them. logpdf must be concave on the interval. It returns an error if the bounds are invalid,
+// if suitable starting points cannot be found, or if logpdf is found not to be concave.
+func NewAdaptiveRejection(logpdf func(float64) float64, lower, upper float64) (*ARS, error) {
+	if !(lower < upper) {
+		return nil, errors.New("milkrandom: invalid range for NewAdaptiveRejection")
+	}
+	a := &ARS{logpdf: logpdf, lower: lower, upper: upper}
+	if err := a.initPoints(); err != nil {
+		return nil, err
+	}
+	if err := a.update(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// initPoints chooses starting abscissae. With an infinite bound the outermost point on that side
+// must have a log density sloping towards the interior, so it is pushed outwards until it does.
+func (a *ARS) initPoints() error {
+	var xs []float64
+	switch {
+	case !math.IsInf(a.lower, 0) && !math.IsInf(a.upper, 0):
+		w := a.upper - a.lower
+		xs = []float64{a.lower + 0.1*w, a.lower + 0.5*w, a.lower + 0.9*w}
+	case math.IsInf(a.lower, 0) && math.IsInf(a.upper, 0):
+		xs = []float64{-1, 0, 1}
+	case math.IsInf(a.lower, 0):
+		xs = []float64{a.upper - 2, a.upper - 1, a.upper - 0.5}
+	default:
+		xs = []float64{a.lower + 0.5, a.lower + 1, a.lower + 2}
+	}
+	for _, x := range xs {
+		a.addPoint(x)
+	}
+	if math.IsInf(a.lower, 0) {
+		step := 1.0
+		for i := 0; a.dh[0] <= 0; i++ {
+			if i == 64 {
+				return errors.New("milkrandom: log density does not decay towards the lower bound")
+			}
+			step *= 2
+			a.addPoint(a.x[0] - step)
+		}
+	}
+	if math.IsInf(a.upper, 0) {
+		step := 1.0
+		for i := 0; a.dh[len(a.dh)-1] >= 0; i++ {
+			if i == 64 {
+				return errors.New("milkrandom: log density does not decay towards the upper bound")
+			}
+			step *= 2
+			a.addPoint(a.x[len(a.x)-1] + step)
+		}
+	}
+	return nil
+}
+
+// addPoint inserts x into the sorted abscissae together with the log density and its derivative.
+func (a *ARS) addPoint(x float64) {
+	i := sort.SearchFloat64s(a.x, x)
+	if i < len(a.x) && a.x[i] == x {
+		return
+	}
+	h, dh := a.logpdf(x), a.derivative(x)
+	a.x = append(a.x, 0)
+	a.h = append(a.h, 0)
+	a.dh = append(a.dh, 0)
+	copy(a.x[i+1:], a.x[i:])
+	copy(a.h[i+1:], a.h[i:])
+	copy(a.dh[i+1:], a.dh[i:])
+	a.x[i], a.h[i], a.dh[i] = x, h, dh
+}
+
+// derivative estimates the derivative of logpdf at x by central differences, falling back to
+// one-sided differences next to a finite bound.
+func (a *ARS) derivative(x float64) float64 {
+	eps := 1e-6 * math.Max(1, math.Abs(x))
+	lo, hi := x-eps, x+eps
+	if lo < a.lower {
+		lo = x
+	}
+	if hi > a.upper {
+		hi = x
+	}
+	return (a.logpdf(hi) - a.logpdf(lo)) / (hi - lo)
+}
+
+// update checks concavity and rebuilds the envelope breakpoints and cumulative masses.
+func (a *ARS) update() error {
+	k := len(a.x)
+	for i := 0; i < k; i++ {
+		if math.IsNaN(a.h[i]) || math.IsInf(a.h[i], 0) || math.IsNaN(a.dh[i]) || math.IsInf(a.dh[i], 0) {
+			return errors.New("milkrandom: log density is not finite inside the range")
+		}
+		if i > 0 && a.dh[i] > a.dh[i-1]+1e-6*math.Max(1, math.Abs(a.dh[i-1])) {
+			return errors.New("milkrandom: log density is not concave")
+		}
+	}
+	a.z = append(a.z[:0], a.lower)
+	for i := 0; i+1 < k; i++ {
+		var z float64
+		if d := a.dh[i] - a.dh[i+1]; d > 1e-12 {
+			z = (a.h[i+1] - a.h[i] - a.x[i+1]*a.dh[i+1] + a.x[i]*a.dh[i]) / d
+		} else {
+			z = (a.x[i] + a.x[i+1]) / 2
+		}
+		a.z = append(a.z, math.Max(a.x[i], math.Min(a.x[i+1], z)))
+	}
+	a.z = append(a.z, a.upper)
+
+	a.offset = math.Inf(-1)
+	for i := 0; i < k; i++ {
+		a.offset = math.Max(a.offset, math.Max(a.hull(i, a.z[i]), a.hull(i, a.z[i+1])))
+	}
+	a.cum = a.cum[:0]
+	total := 0.0
+	for i := 0; i < k; i++ {
+		total += a.segmentMass(i)
+		a.cum = append(a.cum, total)
+	}
+	if !(total > 0) || math.IsInf(total, 0) {
+		return errors.New("milkrandom: envelope of the log density is not integrable")
+	}
+	return nil
+}
+
+// hull returns the tangent at abscissa i evaluated at x.
+func (a *ARS) hull(i int, x float64) float64 {
+	if math.IsInf(x, 0) {
+		if a.dh[i] == 0 {
+			return a.h[i]
+		}
+		return math.Copysign(math.Inf(1), a.dh[i]*x)
+	}
+	return a.h[i] + a.dh[i]*(x-a.x[i])
+}
+
+// segmentMass returns the integral of exp(tangent i - offset) over segment i.
+func (a *ARS) segmentMass(i int) float64 {
+	lo, hi := a.z[i], a.z[i+1]
+	if math.Abs(a.dh[i]) < 1e-12 {
+		return math.Exp(a.h[i]-a.offset) * (hi - lo)
+	}
+	return (math.Exp(a.hull(i, hi)-a.offset) - math.Exp(a.hull(i, lo)-a.offset)) / a.dh[i]
+}
+
+// squeeze returns the chord between the abscissae surrounding x, or -Inf outside them.
+func (a *ARS) squeeze(x float64) float64 {
+	j := sort.SearchFloat64s(a.x, x)
+	if j == 0 || j == len(a.x) {
+		return math.Inf(-1)
+	}
+	x0, x1 := a.x[j-1], a.x[j]
+	return ((x1-x)*a.h[j-1] + (x-x0)*a.h[j]) / (x1 - x0)
+}
+
+// Sample draws a value from the density using src. Points at which the density had to be
+// evaluated are added to the envelope. It panics if the log density turns out not to be concave.
+func (a *ARS) Sample(src Source) float64 {
+	for {
+		i := pickCumulative(src, a.cum)
+		x := a.sampleSegment(src, i)
+		logW := math.Log(float64Open(src))
+		ux := a.hull(i, x)
+		if logW <= a.squeeze(x)-ux {
+			return x
+		}
+		hx := a.logpdf(x)
+		accept := logW <= hx-ux
+		if len(a.x) < arsMaxPoints {
+			a.addPoint(x)
+			if err := a.update(); err != nil {
+				panic(err.Error())
+			}
+		}
+		if accept {
+			return x
+		}
+	}
+}
+
+// sampleSegment draws x from the piecewise-exponential envelope restricted to segment i.
+func (a *ARS) sampleSegment(src Source, i int) float64 {
+	lo, hi := a.z[i], a.z[i+1]
+	u := float64Open(src)
+	d := a.dh[i]
+	if math.Abs(d) < 1e-12 {
+		return lo + u*(hi-lo)
+	}
+	elo := math.Exp(a.hull(i, lo) - a.offset)
+	x := a.x[i] + (math.Log(elo+u*a.segmentMass(i)*d)+a.offset-a.h[i])/d
+	return math.Max(lo, math.Min(hi, x))
+}
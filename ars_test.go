@@ -0,0 +1,60 @@
+package milkrandom
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+func TestAdaptiveRejectionNormalQuantiles(t *testing.T) {
+	for _, c := range []struct {
+		name         string
+		lower, upper float64
+		quantiles    map[float64]float64
+	}{
+		{"normal", math.Inf(-1), math.Inf(1), map[float64]float64{
+			0.05: -1.6449, 0.25: -0.6745, 0.5: 0, 0.75: 0.6745, 0.95: 1.6449,
+		}},
+		// The standard normal restricted to [0, inf) has quantile q at the normal's (1+q)/2.
+		{"half-normal", 0, math.Inf(1), map[float64]float64{
+			0.25: 0.3186, 0.5: 0.6745, 0.9: 1.6449,
+		}},
+		{"truncated", -1, 1, map[float64]float64{0.5: 0}},
+	} {
+		a, err := NewAdaptiveRejection(func(x float64) float64 { return -x * x / 2 }, c.lower, c.upper)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		src := newTestSource(1)
+		const n = 100000
+		xs := make([]float64, n)
+		for i := range xs {
+			xs[i] = a.Sample(src)
+			if xs[i] < c.lower || xs[i] > c.upper {
+				t.Fatalf("%s: Sample() = %v, outside [%v, %v]", c.name, xs[i], c.lower, c.upper)
+			}
+		}
+		sort.Float64s(xs)
+		for q, want := range c.quantiles {
+			if got := xs[int(q*n)]; math.Abs(got-want) > 0.03 {
+				t.Errorf("%s: quantile %v = %v, want %v", c.name, q, got, want)
+			}
+		}
+	}
+}
+
+func TestNewAdaptiveRejectionInvalid(t *testing.T) {
+	normal := func(x float64) float64 { return -x * x / 2 }
+	if _, err := NewAdaptiveRejection(normal, 1, 1); err == nil {
+		t.Error("NewAdaptiveRejection accepted an empty range")
+	}
+	if _, err := NewAdaptiveRejection(normal, 2, -2); err == nil {
+		t.Error("NewAdaptiveRejection accepted lower > upper")
+	}
+	if _, err := NewAdaptiveRejection(func(x float64) float64 { return x * x }, -1, 1); err == nil {
+		t.Error("NewAdaptiveRejection accepted a convex log density")
+	}
+	if _, err := NewAdaptiveRejection(func(x float64) float64 { return x }, 0, math.Inf(1)); err == nil {
+		t.Error("NewAdaptiveRejection accepted a log density that grows towards an infinite bound")
+	}
+}
@@ -0,0 +1,51 @@
+package milkrandom
+
+import (
+	"math"
+	"time"
+)
+
+// DecorrelatedJitter returns the next delay of an exponential backoff using the decorrelated
+// jitter scheme, min(max, uniform(base, previous*3)). Each delay depends on the previous one
+// rather than on the attempt number, so retries grow on average while staying spread out.
+// Pass base as previous for the first retry; a previous below base is treated as base. The
+// result always lies in [base, max]. It panics if base <= 0 or max < base.
+func DecorrelatedJitter(src Source, base, max, previous time.Duration) time.Duration {
+	if base <= 0 {
+		panic("milkrandom: argument base to DecorrelatedJitter is <= 0")
+	}
+	if max < base {
+		panic("milkrandom: argument max to DecorrelatedJitter is < base")
+	}
+	if previous < base {
+		previous = base
+	}
+	hi := time.Duration(math.MaxInt64)
+	if previous <= hi/3 {
+		hi = previous * 3
+	}
+	d := base + time.Duration(uint64n(src, uint64(hi-base)+1))
+	if d > max {
+		return max
+	}
+	return d
+}
+
+// RetrySchedule returns the delays before each of attempts retries, computed up front with
+// DecorrelatedJitter starting from base, so a retry policy can be inspected or logged before it
+// runs. Every delay lies in [base, max]. It panics if attempts < 0, base <= 0 or max < base.
+func RetrySchedule(src Source, attempts int, base, max time.Duration) []time.Duration {
+	if attempts < 0 {
+		panic("milkrandom: argument attempts to RetrySchedule is < 0")
+	}
+	if base <= 0 || max < base {
+		panic("milkrandom: invalid backoff range for RetrySchedule")
+	}
+	schedule := make([]time.Duration, attempts)
+	prev := base
+	for i := range schedule {
+		prev = DecorrelatedJitter(src, base, max, prev)
+		schedule[i] = prev
+	}
+	return schedule
+}
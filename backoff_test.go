@@ -0,0 +1,92 @@
+package milkrandom
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDecorrelatedJitter(t *testing.T) {
+	const base, max = 10 * time.Millisecond, time.Hour
+	const chains, steps = 5000, 6
+	src := newTestSource(1)
+	means := make([]float64, steps)
+	for c := 0; c < chains; c++ {
+		prev := base
+		for i := 0; i < steps; i++ {
+			d := DecorrelatedJitter(src, base, max, prev)
+			if d < base || d > max || d > 3*prev {
+				t.Fatalf("DecorrelatedJitter(%v, %v, %v) = %v, outside [%v, min(%v, 3*previous)]", base, max, prev, d, base, max)
+			}
+			means[i] += float64(d) / chains
+			prev = d
+		}
+	}
+	// Far from max, E[next] = (base + 3*previous) / 2, so the mean delay grows at every step.
+	for i := 1; i < steps; i++ {
+		if means[i] <= means[i-1] {
+			t.Errorf("mean delay at retry %d is %v, not above %v at retry %d", i+1, time.Duration(means[i]), time.Duration(means[i-1]), i)
+		}
+	}
+	if want := (float64(base) + 3*float64(base)) / 2; math.Abs(means[0]-want) > 0.02*want {
+		t.Errorf("mean first delay = %v, want about %v", time.Duration(means[0]), time.Duration(want))
+	}
+
+	// With a low max the delays saturate at max.
+	for i := 0; i < 100; i++ {
+		if d := DecorrelatedJitter(src, base, 20*time.Millisecond, time.Second); d != 20*time.Millisecond {
+			t.Fatalf("DecorrelatedJitter with previous far above max = %v, want max", d)
+		}
+	}
+	if d := DecorrelatedJitter(src, base, time.Duration(math.MaxInt64), time.Duration(math.MaxInt64/2)); d < base {
+		t.Errorf("DecorrelatedJitter with a huge previous = %v, below base", d)
+	}
+
+	for _, c := range [][2]time.Duration{{0, time.Second}, {time.Second, time.Millisecond}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("DecorrelatedJitter(base %v, max %v) did not panic", c[0], c[1])
+				}
+			}()
+			DecorrelatedJitter(src, c[0], c[1], c[0])
+		}()
+	}
+}
+
+func TestRetrySchedule(t *testing.T) {
+	const base, max = time.Millisecond, time.Second
+	a := RetrySchedule(newTestSource(7), 20, base, max)
+	b := RetrySchedule(newTestSource(7), 20, base, max)
+	if len(a) != 20 {
+		t.Fatalf("RetrySchedule returned %d delays, want 20", len(a))
+	}
+	src, prev := newTestSource(7), base
+	for i, d := range a {
+		if prev = DecorrelatedJitter(src, base, max, prev); d != prev {
+			t.Errorf("delay %d = %v, want the DecorrelatedJitter chain's %v", i, d, prev)
+		}
+		if d < base || d > max {
+			t.Errorf("delay %d = %v, outside [%v, %v]", i, d, base, max)
+		}
+		if d != b[i] {
+			t.Errorf("delay %d differs between sources with the same seed", i)
+		}
+	}
+	if len(RetrySchedule(newTestSource(7), 0, base, max)) != 0 {
+		t.Error("RetrySchedule with no attempts returned delays")
+	}
+	for _, c := range []struct {
+		attempts  int
+		base, max time.Duration
+	}{{-1, base, max}, {5, 0, max}, {5, max, base}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("RetrySchedule(%d, %v, %v) did not panic", c.attempts, c.base, c.max)
+				}
+			}()
+			RetrySchedule(newTestSource(7), c.attempts, c.base, c.max)
+		}()
+	}
+}
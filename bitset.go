@@ -0,0 +1,77 @@
+package milkrandom
+
+import "math/bits"
+
+// bitsetPrecision is the number of binary digits of p used by NewBitset.
+const bitsetPrecision = 32
+
+// Bitset is a fixed-size set of random bits.
+type Bitset struct {
+	words []uint64
+	n     int
+}
+
+// NewBitset creates a new Bitset of n bits in which each bit is set independently with probability p.
+// Bits are produced a whole word at a time by combining draws according to the binary expansion of p,
+// so p = 0.5 costs one draw per 64 bits and any p costs at most 32 draws per 64 bits.
+// p is rounded to a multiple of 2^-32. It panics if n < 0 or p is not in [0, 1].
+func NewBitset(src Source, n int, p float64) *Bitset {
+	if n < 0 {
+		panic("milkrandom: argument n to NewBitset is < 0")
+	}
+	if !(p >= 0 && p <= 1) {
+		panic("milkrandom: argument p to NewBitset is not in [0, 1]")
+	}
+	b := &Bitset{words: make([]uint64, (n+63)/64), n: n}
+	q := uint64(p*(1<<bitsetPrecision) + 0.5)
+	for i := range b.words {
+		b.words[i] = bernoulliWord(src, q)
+	}
+	if r := n % 64; r != 0 {
+		b.words[len(b.words)-1] &= 1<<r - 1
+	}
+	return b
+}
+
+// bernoulliWord returns a word whose bits are each set with probability q/2^bitsetPrecision.
+// The digits of q are consumed from least to most significant: OR-ing with a fresh word maps
+// a bit probability P to 1/2 + P/2, and AND-ing maps it to P/2.
+func bernoulliWord(src Source, q uint64) uint64 {
+	if q >= 1<<bitsetPrecision {
+		return ^uint64(0)
+	}
+	if q == 0 {
+		return 0
+	}
+	var w uint64
+	for i := bits.TrailingZeros64(q); i < bitsetPrecision; i++ {
+		if q&(1<<i) != 0 {
+			w |= src.Uint64()
+		} else {
+			w &= src.Uint64()
+		}
+	}
+	return w
+}
+
+// Len returns the number of bits in the set.
+func (b *Bitset) Len() int {
+	return b.n
+}
+
+// Get reports whether bit i is set. It panics if i is out of range.
+func (b *Bitset) Get(i int) bool {
+	if i < 0 || i >= b.n {
+		panic("milkrandom: Bitset index out of range")
+	}
+	return b.words[i/64]&(1<<(i%64)) != 0
+}
+
+// Count returns the number of set bits.
+func (b *Bitset) Count() int {
+	c := 0
+	for _, w := range b.words {
+		c += bits.OnesCount64(w)
+	}
+	return c
+}
@@ -0,0 +1,42 @@
+package milkrandom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBitsetFraction(t *testing.T) {
+	for _, p := range []float64{0, 0.1, 0.5, 0.73, 1} {
+		const n = 200000
+		b := NewBitset(newTestSource(1), n, p)
+		if b.Len() != n {
+			t.Fatalf("Len() = %d, want %d", b.Len(), n)
+		}
+		count := 0
+		for i := 0; i < n; i++ {
+			if b.Get(i) {
+				count++
+			}
+		}
+		if count != b.Count() {
+			t.Errorf("p = %v: Count() = %d, but %d bits are set", p, b.Count(), count)
+		}
+		if frac := float64(count) / n; math.Abs(frac-p) > 0.005 {
+			t.Errorf("p = %v: fraction of set bits = %v", p, frac)
+		}
+	}
+}
+
+func TestBitsetReproducible(t *testing.T) {
+	a := NewBitset(newTestSource(4), 1000, 0.3)
+	b := NewBitset(newTestSource(4), 1000, 0.3)
+	for i := 0; i < 1000; i++ {
+		if a.Get(i) != b.Get(i) {
+			t.Fatalf("bit %d differs between bitsets with the same seed", i)
+		}
+	}
+	// Bits past n in the last word must stay clear.
+	if c := NewBitset(newTestSource(4), 70, 1).Count(); c != 70 {
+		t.Errorf("Count() of 70 bits with p = 1 is %d", c)
+	}
+}
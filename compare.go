@@ -0,0 +1,12 @@
+package milkrandom
+
+// StreamsEqual draws up to n values from each of a and b and reports whether they are equal.
+// It stops at the first difference and returns its index, or -1 if all n values match.
+func StreamsEqual(a, b Source, n int) (equal bool, index int) {
+	for i := 0; i < n; i++ {
+		if a.Uint64() != b.Uint64() {
+			return false, i
+		}
+	}
+	return true, -1
+}
@@ -0,0 +1,39 @@
+package milkrandom
+
+import "testing"
+
+func TestStreamsEqual(t *testing.T) {
+	if equal, index := StreamsEqual(newTestSource(1), newTestSource(1), 1000); !equal || index != -1 {
+		t.Errorf("StreamsEqual on identically seeded sources = %v, %d; want true, -1", equal, index)
+	}
+
+	// Once b is advanced by one value, the streams are offset and differ at index 0.
+	a, b := newTestSource(1), newTestSource(1)
+	b.Uint64()
+	if equal, index := StreamsEqual(a, b, 1000); equal || index != 0 {
+		t.Errorf("StreamsEqual after advancing one source = %v, %d; want false, 0", equal, index)
+	}
+
+	// A source that agrees for the first five values and then diverges.
+	a = newTestSource(1)
+	ref := newTestSource(1)
+	i := 0
+	c := sourceFunc(func() uint64 {
+		v := ref.Uint64()
+		if i++; i > 5 {
+			v++
+		}
+		return v
+	})
+	if equal, index := StreamsEqual(a, c, 1000); equal || index != 5 {
+		t.Errorf("StreamsEqual on streams differing at index 5 = %v, %d; want false, 5", equal, index)
+	}
+	if i != 6 {
+		t.Errorf("StreamsEqual drew %d values after the first difference, want it to stop there", i-6)
+	}
+}
+
+// sourceFunc adapts a function to the Source interface.
+type sourceFunc func() uint64
+
+func (f sourceFunc) Uint64() uint64 { return f() }
@@ -0,0 +1,52 @@
+package milkrandom
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestConfigRoundTrip(t *testing.T) {
+	for name, newSource := range configAlgorithms {
+		src := newSource()
+		src.(seeder).Seed(1)
+		for i := 0; i < 10; i++ {
+			src.Uint64()
+		}
+		data, err := MarshalConfig(src)
+		if err != nil {
+			t.Fatalf("%s: MarshalConfig: %v", name, err)
+		}
+		var c generatorConfig
+		if err := json.Unmarshal(data, &c); err != nil {
+			t.Fatalf("%s: config %s is not valid JSON: %v", name, data, err)
+		}
+		if c.Algorithm != name {
+			t.Errorf("%s: config records algorithm %q", name, c.Algorithm)
+		}
+		restored, err := UnmarshalConfig(data)
+		if err != nil {
+			t.Fatalf("%s: UnmarshalConfig: %v", name, err)
+		}
+		if got, _ := configAlgorithm(restored); got != name {
+			t.Errorf("%s: UnmarshalConfig returned a %s generator", name, got)
+		}
+		if equal, i := StreamsEqual(src, restored, 100); !equal {
+			t.Errorf("%s: restored generator differs from the original at output %d", name, i)
+		}
+	}
+}
+
+func TestConfigErrors(t *testing.T) {
+	if _, err := UnmarshalConfig([]byte(`{"algorithm":"mt19937","state":{}}`)); err == nil {
+		t.Error("UnmarshalConfig accepted an unknown algorithm")
+	}
+	if _, err := UnmarshalConfig([]byte(`{"algorithm":"pcg32","state":{"inc":"xyz"}}`)); err == nil {
+		t.Error("UnmarshalConfig accepted an invalid state")
+	}
+	if _, err := UnmarshalConfig([]byte(`not json`)); err == nil {
+		t.Error("UnmarshalConfig accepted malformed JSON")
+	}
+	if _, err := MarshalConfig(constSource(1)); err == nil {
+		t.Error("MarshalConfig accepted a source that is not a generator of this module")
+	}
+}
@@ -0,0 +1,28 @@
+package milkrandom
+
+// CountingSource wraps a Source and counts how many values have been drawn from it.
+type CountingSource struct {
+	src   Source
+	count uint64
+}
+
+// NewCountingSource creates a new CountingSource wrapping src.
+func NewCountingSource(src Source) *CountingSource {
+	return &CountingSource{src: src}
+}
+
+// Uint64 generates a random 64-bit unsigned integer from the wrapped source and counts the draw.
+func (c *CountingSource) Uint64() uint64 {
+	c.count++
+	return c.src.Uint64()
+}
+
+// Count returns the number of values drawn since creation or the last call to ResetCount.
+func (c *CountingSource) Count() uint64 {
+	return c.count
+}
+
+// ResetCount sets the draw count back to zero.
+func (c *CountingSource) ResetCount() {
+	c.count = 0
+}
@@ -0,0 +1,30 @@
+package milkrandom
+
+import "time"
+
+// Date returns a uniformly random midnight in [start, end), in the location of start.
+// Midnights are counted in calendar days, so days shortened or lengthened by daylight saving
+// transitions are as likely as any other. It panics if start is not before end or if no
+// midnight falls in the range.
+func Date(src Source, start, end time.Time) time.Time {
+	if !start.Before(end) {
+		panic("milkrandom: invalid range for Date")
+	}
+	y, m, d := start.Date()
+	first := time.Date(y, m, d, 0, 0, 0, 0, start.Location())
+	if first.Before(start) {
+		first = first.AddDate(0, 0, 1)
+	}
+	if !first.Before(end) {
+		panic("milkrandom: no midnight in range for Date")
+	}
+	// Estimate the number of days from the Unix times, then correct for daylight saving shifts.
+	days := int((end.Unix() - first.Unix()) / (24 * 60 * 60))
+	for first.AddDate(0, 0, days).Before(end) {
+		days++
+	}
+	for days > 1 && !first.AddDate(0, 0, days-1).Before(end) {
+		days--
+	}
+	return first.AddDate(0, 0, intn(src, days))
+}
@@ -0,0 +1,66 @@
+package milkrandom
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDate(t *testing.T) {
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)
+	src := newTestSource(1)
+	counts := make(map[time.Time]int)
+	const n = 9000
+	for i := 0; i < n; i++ {
+		d := Date(src, start, end)
+		if h, m, s := d.Clock(); h != 0 || m != 0 || s != 0 || d.Nanosecond() != 0 {
+			t.Fatalf("Date() = %v, not a midnight", d)
+		}
+		if d.Before(start) || !d.Before(end) {
+			t.Fatalf("Date() = %v, outside [%v, %v)", d, start, end)
+		}
+		counts[d]++
+	}
+	// The midnights of January 2 to 10.
+	if len(counts) != 9 {
+		t.Errorf("Date returned %d distinct days, want 9", len(counts))
+	}
+	for d, c := range counts {
+		if c < 850 || c > 1150 {
+			t.Errorf("Date returned %v %d times, want about 1000", d.Format("2006-01-02"), c)
+		}
+	}
+
+	a := Date(newTestSource(7), start, end)
+	if b := Date(newTestSource(7), start, end); !a.Equal(b) {
+		t.Errorf("Date is %v and %v for sources with the same seed", a, b)
+	}
+
+	if loc, err := time.LoadLocation("America/New_York"); err == nil {
+		// March 10, 2024 is 23 hours long in New York.
+		start := time.Date(2024, 3, 9, 0, 0, 0, 0, loc)
+		end := time.Date(2024, 3, 12, 0, 0, 0, 0, loc)
+		seen := make(map[int]bool)
+		for i := 0; i < 300; i++ {
+			d := Date(src, start, end)
+			if d.Hour() != 0 || d.Location() != loc {
+				t.Fatalf("Date() = %v across a daylight saving change, not a midnight in %v", d, loc)
+			}
+			seen[d.Day()] = true
+		}
+		if len(seen) != 3 {
+			t.Errorf("Date returned %d distinct days across a daylight saving change, want 3", len(seen))
+		}
+	}
+
+	for _, r := range [][2]time.Time{{end, start}, {start, start}, {start, start.Add(time.Hour)}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Date(%v, %v) did not panic", r[0], r[1])
+				}
+			}()
+			Date(src, r[0], r[1])
+		}()
+	}
+}
@@ -0,0 +1,194 @@
+package milkrandom
+
+import "math"
+
+// InverseCDF draws a value from a continuous distribution given its inverse cumulative
+// distribution function. The uniform value passed to icdf lies in the open range
+// (0.0, 1.0), so functions that diverge at 0 or 1 are safe to use.
+func InverseCDF(src Source, icdf func(u float64) float64) float64 {
+	return icdf(float64Open(src))
+}
+
+// Clamped draws a value from sampler using src and clamps it to [lo, hi]. Values already inside
+// the range are returned unchanged; a NaN from sampler is passed through. It panics if lo > hi.
+func Clamped(src Source, sampler func(Source) float64, lo, hi float64) float64 {
+	if !(lo <= hi) {
+		panic("milkrandom: invalid range for Clamped")
+	}
+	x := sampler(src)
+	if x < lo {
+		return lo
+	}
+	if x > hi {
+		return hi
+	}
+	return x
+}
+
+// NormFloat64 generates a normally distributed float64 with mean 0 and standard deviation 1
+// using the Box–Muller transform.
+func NormFloat64(src Source) float64 {
+	u1 := float64Open(src)
+	u2 := float64From(src)
+	return math.Sqrt(-2*math.Log(u1)) * math.Cos(2*math.Pi*u2)
+}
+
+// ExpFloat64 generates an exponentially distributed float64 with rate 1 (mean 1) by inversion.
+// The uniform draw lies in (0.0, 1.0), so the result is always finite and positive.
+// To produce a distribution with a different rate, divide the result by the rate.
+func ExpFloat64(src Source) float64 {
+	return -math.Log(float64Open(src))
+}
+
+// BivariateNormal generates a pair of standard normal values with correlation rho, computing
+// y = rho*x + sqrt(1-rho²)*z from independent standard normals x and z. It panics if rho is
+// not in [-1, 1].
+func BivariateNormal(src Source, rho float64) (x, y float64) {
+	if !(rho >= -1 && rho <= 1) {
+		panic("milkrandom: argument to BivariateNormal is not in [-1, 1]")
+	}
+	x = NormFloat64(src)
+	z := NormFloat64(src)
+	return x, rho*x + math.Sqrt(1-rho*rho)*z
+}
+
+// BenfordDigit generates a leading digit in [1, 9] following Benford's law, in which digit d has
+// probability log10(1+1/d). It inverts the cumulative distribution log10(d+1) directly, returning
+// the integer part of 10^u for a uniform u, which is equivalent to weighted selection over the digits.
+func BenfordDigit(src Source) int {
+	d := int(math.Pow(10, float64From(src)))
+	if d > 9 { // guard against rounding at the upper edge
+		d = 9
+	}
+	return d
+}
+
+// NormInt64 generates a normally distributed value with the given mean and standard deviation,
+// rounded to the nearest integer. It panics if stddev < 0.
+func NormInt64(src Source, mean, stddev float64) int64 {
+	if stddev < 0 {
+		panic("milkrandom: argument stddev to NormInt64 is < 0")
+	}
+	return int64(math.Round(mean + stddev*NormFloat64(src)))
+}
+
+// NormInt32Fixed generates an approximately normally distributed value in Q16.16 fixed point
+// with the given mean and standard deviation, also in Q16.16. It sums twelve 16-bit uniforms
+// (the Irwin–Hall approximation) and uses only integer arithmetic, which suits targets without
+// fast floating point. Results lie within six standard deviations of the mean and saturate at
+// the limits of int32. It panics if stddevQ16 < 0.
+func NormInt32Fixed(src Source, meanQ16, stddevQ16 int32) int32 {
+	if stddevQ16 < 0 {
+		panic("milkrandom: argument stddevQ16 to NormInt32Fixed is < 0")
+	}
+	var sum int64
+	for i := 0; i < 3; i++ {
+		v := src.Uint64()
+		sum += int64(v&0xffff) + int64(v>>16&0xffff) + int64(v>>32&0xffff) + int64(v>>48)
+	}
+	z := sum - 6<<16 // standard normal approximation in Q16.16
+	r := int64(meanQ16) + (int64(stddevQ16)*z)>>16
+	if r > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	if r < math.MinInt32 {
+		return math.MinInt32
+	}
+	return int32(r)
+}
+
+// TruncatedNormal generates a normally distributed float64 with the given mean and standard deviation,
+// conditioned to lie in [lower, upper]. It inverts the normal CDF over the truncated range, which needs a
+// single uniform regardless of how severe the truncation is. Where the CDF cannot tell the bounds apart
+// it falls back to rejection sampling: exponential proposals for wide ranges far in the tail, and uniform
+// proposals for ranges so narrow that the density is nearly flat across them. It panics if stddev <= 0
+// or lower >= upper.
+func TruncatedNormal(src Source, mean, stddev, lower, upper float64) float64 {
+	if !(stddev > 0) {
+		panic("milkrandom: argument stddev to TruncatedNormal is <= 0")
+	}
+	if !(lower < upper) {
+		panic("milkrandom: invalid range for TruncatedNormal")
+	}
+	a, b := (lower-mean)/stddev, (upper-mean)/stddev
+	// Work in the lower half, where the CDF is computed accurately, by mirroring if needed.
+	flip := a > 0
+	if flip {
+		a, b = -b, -a
+	}
+	var z float64
+	pa, pb := normalCDF(a), normalCDF(b)
+	switch {
+	case pb > pa:
+		z = -math.Sqrt2 * math.Erfcinv(2*(pa+float64Open(src)*(pb-pa)))
+		z = math.Max(a, math.Min(b, z))
+	case b < 0 && (b-a)*-(a+b) > 2:
+		// The range lies in the lower tail and is wide enough for exponential proposals to land in it.
+		z = -normalTail(src, -b, -a)
+	default:
+		z = normalNarrow(src, a, b)
+	}
+	if flip {
+		z = -z
+	}
+	return mean + stddev*z
+}
+
+// normalCDF returns the standard normal cumulative distribution function at z.
+func normalCDF(z float64) float64 {
+	return 0.5 * math.Erfc(-z/math.Sqrt2)
+}
+
+// normalTail samples a standard normal conditioned to lie in [c, d] for c > 0 using Robert's
+// exponential rejection sampler.
+func normalTail(src Source, c, d float64) float64 {
+	alpha := (c + math.Sqrt(c*c+4)) / 2
+	for {
+		y := c - math.Log(float64Open(src))/alpha
+		if y > d {
+			continue
+		}
+		if float64From(src) <= math.Exp(-(y-alpha)*(y-alpha)/2) {
+			return y
+		}
+	}
+}
+
+// normalNarrow samples a standard normal conditioned to lie in [a, b] for a <= 0 by rejection from
+// uniform proposals. It is used for ranges narrow enough that (b-a)*(|a|+|b|) <= 2, where the density
+// varies by at most a factor of e across the range, so each proposal is accepted with probability at
+// least 1/e.
+func normalNarrow(src Source, a, b float64) float64 {
+	peak := math.Min(b, 0) // the point of [a, b] closest to the mean
+	for {
+		z := a + float64From(src)*(b-a)
+		if z > b {
+			z = b
+		}
+		if float64From(src) <= math.Exp((peak*peak-z*z)/2) {
+			return z
+		}
+	}
+}
+
+// StickBreaking returns the first maxSticks weights of the GEM(alpha) stick-breaking construction
+// of a Dirichlet process: each weight is a Beta(1, alpha) fraction of the stick left over by the
+// previous ones. The weights sum to at most 1, approaching it as maxSticks grows, and smaller alpha
+// concentrates the mass in fewer sticks. Beta(1, alpha) is sampled by inversion as 1-U^(1/alpha).
+// It panics if alpha is not positive and finite or maxSticks < 0.
+func StickBreaking(src Source, alpha float64, maxSticks int) []float64 {
+	if !(alpha > 0) || math.IsInf(alpha, 1) {
+		panic("milkrandom: argument alpha to StickBreaking must be positive and finite")
+	}
+	if maxSticks < 0 {
+		panic("milkrandom: argument maxSticks to StickBreaking is < 0")
+	}
+	weights := make([]float64, maxSticks)
+	remaining := 1.0
+	for i := range weights {
+		b := -math.Expm1(math.Log(float64Open(src)) / alpha) // 1 - U^(1/alpha)
+		weights[i] = remaining * b
+		remaining -= weights[i]
+	}
+	return weights
+}
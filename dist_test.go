@@ -0,0 +1,317 @@
+package milkrandom
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+func TestInverseCDFExponential(t *testing.T) {
+	src := newTestSource(1)
+	const n = 100000
+	inv := make([]float64, n)
+	exp := make([]float64, n)
+	for i := range inv {
+		inv[i] = InverseCDF(src, func(u float64) float64 { return -math.Log(1 - u) })
+		exp[i] = ExpFloat64(src)
+	}
+	sort.Float64s(inv)
+	sort.Float64s(exp)
+	for _, q := range []float64{0.1, 0.25, 0.5, 0.75, 0.9, 0.99} {
+		want := -math.Log(1 - q)
+		i := int(q * n)
+		if math.Abs(inv[i]-want) > 0.03*want+0.01 {
+			t.Errorf("InverseCDF quantile %v = %v, want %v", q, inv[i], want)
+		}
+		if math.Abs(inv[i]-exp[i]) > 0.03*want+0.01 {
+			t.Errorf("quantile %v: InverseCDF %v, ExpFloat64 %v", q, inv[i], exp[i])
+		}
+	}
+}
+
+func TestInverseCDFOpenInterval(t *testing.T) {
+	src := newTestSource(1)
+	for i := 0; i < 100000; i++ {
+		InverseCDF(src, func(u float64) float64 {
+			if u <= 0 || u >= 1 {
+				t.Fatalf("icdf called with %v", u)
+			}
+			return u
+		})
+	}
+}
+
+func TestNormInt64Moments(t *testing.T) {
+	src := newTestSource(1)
+	const n = 200000
+	const mean, stddev = 10.0, 3.0
+	var sum, sumSq float64
+	for i := 0; i < n; i++ {
+		v := float64(NormInt64(src, mean, stddev))
+		sum += v
+		sumSq += v * v
+	}
+	m := sum / n
+	variance := sumSq/n - m*m
+	// Rounding to integers adds about 1/12 to the variance (Sheppard's correction).
+	want := stddev*stddev + 1.0/12
+	if math.Abs(m-mean) > 0.03 {
+		t.Errorf("mean = %v, want about %v", m, mean)
+	}
+	if math.Abs(variance-want) > 0.02*want {
+		t.Errorf("variance = %v, want about %v", variance, want)
+	}
+}
+
+func TestNormInt32FixedMoments(t *testing.T) {
+	src := newTestSource(1)
+	const n = 200000
+	const meanQ16, stddevQ16 = 5 << 16, 2 << 16
+	var sum, sumSq float64
+	within1 := 0
+	for i := 0; i < n; i++ {
+		v := float64(NormInt32Fixed(src, meanQ16, stddevQ16)) / (1 << 16)
+		sum += v
+		sumSq += v * v
+		if math.Abs(v-5) < 2 {
+			within1++
+		}
+		if math.Abs(v-5) > 12 {
+			t.Fatalf("NormInt32Fixed returned %v, more than six standard deviations from the mean", v)
+		}
+	}
+	mean := sum / n
+	stddev := math.Sqrt(sumSq/n - mean*mean)
+	if math.Abs(mean-5) > 0.02 {
+		t.Errorf("mean = %v, want about 5", mean)
+	}
+	if math.Abs(stddev-2) > 0.02 {
+		t.Errorf("standard deviation = %v, want about 2", stddev)
+	}
+	// About 68.3% of a normal distribution lies within one standard deviation of the mean.
+	if frac := float64(within1) / n; math.Abs(frac-0.683) > 0.01 {
+		t.Errorf("fraction within one standard deviation = %v, want about 0.683", frac)
+	}
+}
+
+func TestTruncatedNormal(t *testing.T) {
+	pdf := func(z float64) float64 { return math.Exp(-z*z/2) / math.Sqrt(2*math.Pi) }
+	for _, c := range []struct{ mean, stddev, lower, upper float64 }{
+		{0, 1, -1, 1},
+		{0, 1, 0, math.Inf(1)},
+		{5, 2, 6, 7},
+		{0, 1, -8, -6}, // severe truncation in the lower tail
+		{10, 3, 22, 40},
+	} {
+		src := newTestSource(1)
+		const n = 50000
+		sum := 0.0
+		for i := 0; i < n; i++ {
+			v := TruncatedNormal(src, c.mean, c.stddev, c.lower, c.upper)
+			if v < c.lower || v > c.upper {
+				t.Fatalf("TruncatedNormal(%v, %v, %v, %v) = %v, out of bounds", c.mean, c.stddev, c.lower, c.upper, v)
+			}
+			sum += v
+		}
+		// The mean of a normal truncated to [a, b] in standard units is
+		// mean + stddev * (pdf(a) - pdf(b)) / (cdf(b) - cdf(a)).
+		a, b := (c.lower-c.mean)/c.stddev, (c.upper-c.mean)/c.stddev
+		want := c.mean + c.stddev*(pdf(a)-pdf(b))/(normalCDF(b)-normalCDF(a))
+		if got := sum / n; math.Abs(got-want) > 0.01*c.stddev {
+			t.Errorf("TruncatedNormal(%v, %v, %v, %v) has mean %v, want %v", c.mean, c.stddev, c.lower, c.upper, got, want)
+		}
+	}
+
+	// So far in the tail that the CDF underflows: the conditional mean of a standard normal
+	// above a is the inverse Mills ratio, about a + 1/a - 2/a^3.
+	src := newTestSource(1)
+	const n = 20000
+	sum := 0.0
+	for i := 0; i < n; i++ {
+		v := TruncatedNormal(src, 0, 1, 40, 41)
+		if v < 40 || v > 41 {
+			t.Fatalf("TruncatedNormal(0, 1, 40, 41) = %v, out of bounds", v)
+		}
+		sum += v
+	}
+	if got, want := sum/n, 40+1.0/40-2.0/(40*40*40); math.Abs(got-want) > 0.002 {
+		t.Errorf("TruncatedNormal(0, 1, 40, 41) has mean %v, want %v", got, want)
+	}
+
+	// Ranges too narrow for the CDF to separate their bounds, at the mean and in either tail,
+	// must still return a value within them rather than loop.
+	for _, c := range [][2]float64{{0, 1e-17}, {-1e-17, 0}, {-2e-17, -1e-17}, {1e-17, 2e-17}, {-1e-300, 1e-300}, {40, 40 + 1e-14}} {
+		for i := 0; i < 100; i++ {
+			if v := TruncatedNormal(src, 0, 1, c[0], c[1]); v < c[0] || v > c[1] {
+				t.Fatalf("TruncatedNormal(0, 1, %v, %v) = %v, outside the range", c[0], c[1], v)
+			}
+		}
+	}
+
+	for _, c := range [][3]float64{{0, 1, 1}, {1, 2, 1}, {-1, 0, 1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("TruncatedNormal(src, 0, %v, %v, %v) did not panic", c[0], c[1], c[2])
+				}
+			}()
+			TruncatedNormal(newTestSource(1), 0, c[0], c[1], c[2])
+		}()
+	}
+}
+
+func TestClamped(t *testing.T) {
+	src := newTestSource(1)
+	wide := func(src Source) float64 { return 10 * NormFloat64(src) }
+	clamped := 0
+	for i := 0; i < 10000; i++ {
+		v := Clamped(src, wide, -5, 5)
+		if v < -5 || v > 5 {
+			t.Fatalf("Clamped = %v, outside [-5, 5]", v)
+		}
+		if v == -5 || v == 5 {
+			clamped++
+		}
+	}
+	if clamped == 0 {
+		t.Error("Clamped never clamped a sampler that often leaves the range")
+	}
+
+	// For a sampler that stays within the bounds Clamped returns exactly what it draws.
+	a, b := newTestSource(7), newTestSource(7)
+	for i := 0; i < 1000; i++ {
+		if got, want := Clamped(a, float64From, 0, 1), float64From(b); got != want {
+			t.Fatalf("Clamped(float64From, 0, 1) = %v, want %v unchanged", got, want)
+		}
+	}
+
+	if v := Clamped(src, func(Source) float64 { return math.NaN() }, 0, 1); !math.IsNaN(v) {
+		t.Errorf("Clamped of NaN = %v, want NaN", v)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Clamped with lo > hi did not panic")
+		}
+	}()
+	Clamped(src, float64From, 1, 0)
+}
+
+func TestBivariateNormal(t *testing.T) {
+	src := newTestSource(1)
+	const n = 100000
+	for _, rho := range []float64{-0.9, -0.3, 0, 0.5, 0.95} {
+		var sx, sy, sxx, syy, sxy float64
+		for i := 0; i < n; i++ {
+			x, y := BivariateNormal(src, rho)
+			sx += x
+			sy += y
+			sxx += x * x
+			syy += y * y
+			sxy += x * y
+		}
+		mx, my := sx/n, sy/n
+		vx, vy := sxx/n-mx*mx, syy/n-my*my
+		corr := (sxy/n - mx*my) / math.Sqrt(vx*vy)
+		if math.Abs(corr-rho) > 0.01 {
+			t.Errorf("rho %v: empirical correlation %v", rho, corr)
+		}
+		if math.Abs(vx-1) > 0.02 || math.Abs(vy-1) > 0.02 {
+			t.Errorf("rho %v: variances %v, %v, want 1", rho, vx, vy)
+		}
+	}
+	for _, rho := range []float64{-1, 1} {
+		if x, y := BivariateNormal(src, rho); y != rho*x {
+			t.Errorf("BivariateNormal(%v) = %v, %v; want y = %v*x", rho, x, y, rho)
+		}
+	}
+	for _, rho := range []float64{-1.01, 1.01, math.NaN()} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("BivariateNormal(%v) did not panic", rho)
+				}
+			}()
+			BivariateNormal(src, rho)
+		}()
+	}
+}
+
+func TestBenfordDigit(t *testing.T) {
+	src := newTestSource(1)
+	const n = 200000
+	var counts [10]int
+	for i := 0; i < n; i++ {
+		d := BenfordDigit(src)
+		if d < 1 || d > 9 {
+			t.Fatalf("BenfordDigit() = %d, not in [1, 9]", d)
+		}
+		counts[d]++
+	}
+	for d := 1; d <= 9; d++ {
+		if got, want := float64(counts[d])/n, math.Log10(1+1/float64(d)); math.Abs(got-want) > 0.004 {
+			t.Errorf("digit %d has frequency %v, want %v", d, got, want)
+		}
+	}
+}
+
+func TestStickBreaking(t *testing.T) {
+	src := newTestSource(1)
+	const alpha, sticks, trials = 2.0, 10, 50000
+	mean := make([]float64, sticks)
+	for i := 0; i < trials; i++ {
+		w := StickBreaking(src, alpha, sticks)
+		if len(w) != sticks {
+			t.Fatalf("StickBreaking returned %d weights, want %d", len(w), sticks)
+		}
+		sum := 0.0
+		for k, x := range w {
+			if x < 0 {
+				t.Fatalf("weight %d is %v", k, x)
+			}
+			sum += x
+			mean[k] += x / trials
+		}
+		if sum > 1+1e-12 {
+			t.Fatalf("weights %v sum to %v > 1", w, sum)
+		}
+	}
+	// E[w_k] = r^k/(1+alpha) with r = alpha/(1+alpha), so the mean weights decrease geometrically.
+	r := alpha / (1 + alpha)
+	for k := range mean {
+		if want := math.Pow(r, float64(k)) / (1 + alpha); math.Abs(mean[k]-want) > 0.005 {
+			t.Errorf("mean of weight %d = %v, want %v", k, mean[k], want)
+		}
+		if k > 0 && mean[k] >= mean[k-1] {
+			t.Errorf("mean weight %d = %v is not below weight %d = %v", k, mean[k], k-1, mean[k-1])
+		}
+	}
+
+	// The expected total is 1 - r^maxSticks, approaching 1.
+	prev := 0.0
+	for _, m := range []int{1, 5, 20, 80} {
+		total := 0.0
+		for i := 0; i < 5000; i++ {
+			for _, x := range StickBreaking(src, alpha, m) {
+				total += x / 5000
+			}
+		}
+		if want := 1 - math.Pow(r, float64(m)); math.Abs(total-want) > 0.01 || total < prev {
+			t.Errorf("mean total of %d sticks = %v, want %v", m, total, want)
+		}
+		prev = total
+	}
+
+	if len(StickBreaking(src, alpha, 0)) != 0 {
+		t.Error("StickBreaking with no sticks returned weights")
+	}
+	for _, a := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("StickBreaking(alpha %v) did not panic", a)
+				}
+			}()
+			StickBreaking(src, a, 5)
+		}()
+	}
+}
@@ -0,0 +1,35 @@
+package milkrandom
+
+// SliceDrawer draws the elements of a slice without replacement, returning each
+// element exactly once in random order. The shuffle is performed lazily, one
+// Fisher–Yates step per call to Next.
+type SliceDrawer[T any] struct {
+	src       Source
+	items     []T
+	remaining int
+}
+
+// NewSliceDrawer creates a new SliceDrawer over a copy of items. The caller's slice is not modified.
+func NewSliceDrawer[T any](src Source, items []T) *SliceDrawer[T] {
+	d := &SliceDrawer[T]{src: src, items: make([]T, len(items))}
+	copy(d.items, items)
+	d.remaining = len(items)
+	return d
+}
+
+// Next returns the next element in random order. It returns false once every element has been drawn.
+func (d *SliceDrawer[T]) Next() (T, bool) {
+	if d.remaining == 0 {
+		var zero T
+		return zero, false
+	}
+	i := intn(d.src, d.remaining)
+	d.remaining--
+	d.items[i], d.items[d.remaining] = d.items[d.remaining], d.items[i]
+	return d.items[d.remaining], true
+}
+
+// Remaining returns the number of elements that have not been drawn yet.
+func (d *SliceDrawer[T]) Remaining() int {
+	return d.remaining
+}
@@ -0,0 +1,37 @@
+package milkrandom
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestSliceDrawer(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e", "f", "g"}
+	draw := func(seed uint64) []string {
+		d := NewSliceDrawer(newTestSource(seed), items)
+		var out []string
+		for {
+			if d.Remaining() != len(items)-len(out) {
+				t.Fatalf("Remaining() = %d after %d draws", d.Remaining(), len(out))
+			}
+			v, ok := d.Next()
+			if !ok {
+				return out
+			}
+			out = append(out, v)
+		}
+	}
+	got := draw(1)
+	sorted := append([]string(nil), got...)
+	sort.Strings(sorted)
+	if !reflect.DeepEqual(sorted, items) {
+		t.Fatalf("drew %v, want each of %v exactly once", got, items)
+	}
+	if again := draw(1); !reflect.DeepEqual(got, again) {
+		t.Errorf("same seed drew %v then %v", got, again)
+	}
+	if items[0] != "a" || items[6] != "g" {
+		t.Error("NewSliceDrawer modified the caller's slice")
+	}
+}
@@ -0,0 +1,54 @@
+package milkrandom
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// EmpiricalCDF samples from a continuous distribution given as a table of its cumulative
+// distribution function, interpolating linearly between the tabulated points.
+type EmpiricalCDF struct {
+	xs, cdf []float64
+}
+
+// NewEmpiricalCDF creates a new EmpiricalCDF from points xs and the values of the CDF at them.
+// It returns an error if the lengths differ or are less than 2, if xs is not sorted or not
+// finite, or if cdf is not non-decreasing from 0 at the first point to 1 at the last.
+func NewEmpiricalCDF(xs, cdf []float64) (*EmpiricalCDF, error) {
+	if len(xs) != len(cdf) {
+		return nil, errors.New("milkrandom: xs and cdf must have the same length")
+	}
+	if len(xs) < 2 {
+		return nil, errors.New("milkrandom: CDF table needs at least two points")
+	}
+	if cdf[0] != 0 || cdf[len(cdf)-1] != 1 {
+		return nil, errors.New("milkrandom: CDF table must start at 0 and end at 1")
+	}
+	for i := range xs {
+		if math.IsNaN(xs[i]) || math.IsInf(xs[i], 0) {
+			return nil, errors.New("milkrandom: points of a CDF table must be finite")
+		}
+		if i > 0 && !(xs[i] >= xs[i-1]) {
+			return nil, errors.New("milkrandom: points of a CDF table must be sorted")
+		}
+		if i > 0 && !(cdf[i] >= cdf[i-1]) {
+			return nil, errors.New("milkrandom: CDF values must be non-decreasing")
+		}
+	}
+	e := &EmpiricalCDF{xs: make([]float64, len(xs)), cdf: make([]float64, len(cdf))}
+	copy(e.xs, xs)
+	copy(e.cdf, cdf)
+	return e, nil
+}
+
+// Sample draws a value from the tabulated distribution using a single uniform draw from src,
+// inverting the piecewise-linear CDF.
+func (e *EmpiricalCDF) Sample(src Source) float64 {
+	u := float64From(src)
+	// cdf[0] is 0 and cdf[len-1] is 1, so for u in [0, 1) the search lands in [1, len-1].
+	i := sort.Search(len(e.cdf), func(i int) bool { return e.cdf[i] > u })
+	x0, x1 := e.xs[i-1], e.xs[i]
+	c0, c1 := e.cdf[i-1], e.cdf[i]
+	return x0 + (u-c0)/(c1-c0)*(x1-x0)
+}
@@ -0,0 +1,51 @@
+package milkrandom
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+func TestEmpiricalCDFQuantiles(t *testing.T) {
+	// Half the mass spread evenly over [0, 1], none in (1, 3) and half over [3, 4].
+	xs := []float64{0, 1, 3, 4}
+	cdf := []float64{0, 0.5, 0.5, 1}
+	e, err := NewEmpiricalCDF(xs, cdf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := newTestSource(1)
+	const n = 100000
+	samples := make([]float64, n)
+	for i := range samples {
+		v := e.Sample(src)
+		if v < 0 || v > 4 || v > 1 && v < 3 {
+			t.Fatalf("Sample() = %v, where the CDF table has no mass", v)
+		}
+		samples[i] = v
+	}
+	sort.Float64s(samples)
+	for q, want := range map[float64]float64{0.1: 0.2, 0.25: 0.5, 0.45: 0.9, 0.55: 3.1, 0.75: 3.5, 0.9: 3.8} {
+		if got := samples[int(q*n)]; math.Abs(got-want) > 0.02 {
+			t.Errorf("quantile %v = %v, want %v", q, got, want)
+		}
+	}
+}
+
+func TestNewEmpiricalCDFInvalid(t *testing.T) {
+	for name, c := range map[string][2][]float64{
+		"mismatched lengths": {{0, 1, 2}, {0, 1}},
+		"single point":       {{0}, {1}},
+		"not starting at 0":  {{0, 1}, {0.1, 1}},
+		"not ending at 1":    {{0, 1}, {0, 0.9}},
+		"decreasing CDF":     {{0, 1, 2}, {0, 0.6, 0.4}},
+		"unsorted points":    {{0, 2, 1}, {0, 0.5, 1}},
+		"infinite point":     {{0, math.Inf(1)}, {0, 1}},
+		"NaN in CDF":         {{0, 1, 2}, {0, math.NaN(), 1}},
+		"NaN point":          {{0, math.NaN(), 2}, {0, 0.5, 1}},
+	} {
+		if _, err := NewEmpiricalCDF(c[0], c[1]); err == nil {
+			t.Errorf("NewEmpiricalCDF accepted a table with %s", name)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package milkrandom
+
+import "errors"
+
+// DicePool rolls dice dice with the given number of sides and counts how many rolls meet or
+// exceed target, as in dice-pool tabletop systems. It returns the number of successes and the
+// individual rolls, each in [1, sides]. It panics if dice, sides or target is not positive.
+func DicePool(src Source, dice, sides, target int) (successes int, rolls []int) {
+	if dice <= 0 || sides <= 0 || target <= 0 {
+		panic("milkrandom: arguments to DicePool must be positive")
+	}
+	rolls = make([]int, dice)
+	for i := range rolls {
+		rolls[i] = intn(src, sides) + 1
+		if rolls[i] >= target {
+			successes++
+		}
+	}
+	return successes, rolls
+}
+
+// LoadedDie is a die whose faces come up with probability proportional to configured weights.
+// Rolls take constant time regardless of the number of faces.
+type LoadedDie struct {
+	table aliasTable
+}
+
+// NewLoadedDie creates a new LoadedDie with one face per weight, face i+1 having weight weights[i].
+// Weights need not sum to 1. It returns an error if there are no weights or any weight is not
+// positive and finite.
+func NewLoadedDie(weights []float64) (*LoadedDie, error) {
+	if len(weights) == 0 {
+		return nil, errors.New("milkrandom: loaded die must have at least one face")
+	}
+	for _, w := range weights {
+		if !(w > 0) {
+			return nil, errors.New("milkrandom: face weights must be positive")
+		}
+	}
+	cum, err := cumulativeWeights(weights)
+	if err != nil {
+		return nil, err
+	}
+	return &LoadedDie{table: newAliasTable(weights, cum[len(cum)-1])}, nil
+}
+
+// Faces returns the number of faces of the die.
+func (d *LoadedDie) Faces() int {
+	return len(d.table.prob)
+}
+
+// Roll rolls the die using src and returns a face in [1, Faces()].
+func (d *LoadedDie) Roll(src Source) int {
+	return d.table.pick(src) + 1
+}
+
+// SpinWheel spins a prize wheel whose segments have the given weights. It returns the winning
+// segment, chosen with probability proportional to its weight, and the sequence of segments
+// passing the pointer during the spin for animation: it starts at a random segment, makes two to
+// four full turns and ends on the winner, so the caller can play it back slowing down towards the
+// end. Segments with weight 0 are not on the wheel and never appear in the sequence.
+// It panics if the weights are invalid as for NewMixture.
+func SpinWheel(src Source, weights []float64) (winner int, spinSequence []int) {
+	cum, err := cumulativeWeights(weights)
+	if err != nil {
+		panic(err.Error())
+	}
+	winner = pickCumulative(src, cum)
+	var segments []int
+	for i, w := range weights {
+		if w > 0 {
+			segments = append(segments, i)
+		}
+	}
+	start := intn(src, len(segments))
+	turns := 2 + intn(src, 3)
+	spinSequence = []int{segments[start]}
+	for i := start; turns > 0 || segments[i] != winner; {
+		i = (i + 1) % len(segments)
+		if i == start {
+			turns--
+		}
+		spinSequence = append(spinSequence, segments[i])
+	}
+	return winner, spinSequence
+}
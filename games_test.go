@@ -0,0 +1,156 @@
+package milkrandom
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestDicePool(t *testing.T) {
+	src := newTestSource(1)
+	faces := make([]int, 10)
+	for i := 0; i < 10000; i++ {
+		successes, rolls := DicePool(src, 5, 10, 8)
+		if len(rolls) != 5 {
+			t.Fatalf("DicePool returned %d rolls, want 5", len(rolls))
+		}
+		want := 0
+		for _, r := range rolls {
+			if r < 1 || r > 10 {
+				t.Fatalf("DicePool rolled %d on a d10", r)
+			}
+			faces[r-1]++
+			if r >= 8 {
+				want++
+			}
+		}
+		if successes != want {
+			t.Fatalf("DicePool counted %d successes in %v, want %d", successes, rolls, want)
+		}
+	}
+	for f, c := range faces {
+		if c < 4500 || c > 5500 {
+			t.Errorf("face %d rolled %d times in 50000 rolls, want about 5000", f+1, c)
+		}
+	}
+
+	sa, ra := DicePool(newTestSource(7), 8, 6, 5)
+	sb, rb := DicePool(newTestSource(7), 8, 6, 5)
+	if sa != sb {
+		t.Errorf("DicePool successes differ for sources with the same seed: %d and %d", sa, sb)
+	}
+	for i := range ra {
+		if ra[i] != rb[i] {
+			t.Errorf("DicePool rolls differ for sources with the same seed: %v and %v", ra, rb)
+			break
+		}
+	}
+
+	for _, args := range [][3]int{{0, 6, 1}, {1, 0, 1}, {1, 6, 0}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("DicePool(src, %d, %d, %d) did not panic", args[0], args[1], args[2])
+				}
+			}()
+			DicePool(src, args[0], args[1], args[2])
+		}()
+	}
+}
+
+func TestLoadedDie(t *testing.T) {
+	weights := []float64{1, 2, 3, 4, 0.5, 9.5}
+	d, err := NewLoadedDie(weights)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Faces() != len(weights) {
+		t.Fatalf("Faces() = %d, want %d", d.Faces(), len(weights))
+	}
+	src := newTestSource(1)
+	const n = 200000
+	counts := make([]int, len(weights))
+	for i := 0; i < n; i++ {
+		f := d.Roll(src)
+		if f < 1 || f > len(weights) {
+			t.Fatalf("Roll() = %d, not in [1, %d]", f, len(weights))
+		}
+		counts[f-1]++
+	}
+	for i, w := range weights {
+		if got, want := float64(counts[i])/n, w/20; math.Abs(got-want) > 0.005 {
+			t.Errorf("face %d came up with frequency %v, want %v", i+1, got, want)
+		}
+	}
+
+	a, b := newTestSource(7), newTestSource(7)
+	for i := 0; i < 100; i++ {
+		if d.Roll(a) != d.Roll(b) {
+			t.Fatalf("roll %d differs between sources with the same seed", i)
+		}
+	}
+
+	for _, w := range [][]float64{nil, {1, 0}, {1, -2}, {math.NaN()}, {math.Inf(1)}} {
+		if _, err := NewLoadedDie(w); err == nil {
+			t.Errorf("NewLoadedDie(%v) accepted invalid weights", w)
+		}
+	}
+}
+
+func TestSpinWheel(t *testing.T) {
+	weights := []float64{1, 0, 3, 2, 4}
+	onWheel := []int{0, 2, 3, 4}
+	position := map[int]int{0: 0, 2: 1, 3: 2, 4: 3}
+	src := newTestSource(1)
+	const n = 100000
+	counts := make([]int, len(weights))
+	for i := 0; i < n; i++ {
+		winner, seq := SpinWheel(src, weights)
+		counts[winner]++
+		if seq[len(seq)-1] != winner {
+			t.Fatalf("spin sequence %v does not end on the winner %d", seq, winner)
+		}
+		// The pointer passes every segment at least twice and moves one segment at a time.
+		if len(seq) < 2*len(onWheel) {
+			t.Fatalf("spin sequence %v makes fewer than two turns", seq)
+		}
+		for _, seg := range seq {
+			if weights[seg] == 0 {
+				t.Fatalf("spin sequence %v passes the zero-weight segment", seq)
+			}
+		}
+		for k := 1; k < len(seq); k++ {
+			if next := onWheel[(position[seq[k-1]]+1)%len(onWheel)]; seq[k] != next {
+				t.Fatalf("spin sequence %v moves from %d to %d, want %d", seq, seq[k-1], seq[k], next)
+			}
+		}
+	}
+	for i, w := range weights {
+		if got, want := float64(counts[i])/n, w/10; math.Abs(got-want) > 0.005 {
+			t.Errorf("segment %d won with frequency %v, want %v", i, got, want)
+		}
+	}
+
+	a, b := newTestSource(7), newTestSource(7)
+	for i := 0; i < 100; i++ {
+		wa, sa := SpinWheel(a, weights)
+		wb, sb := SpinWheel(b, weights)
+		if wa != wb || fmt.Sprint(sa) != fmt.Sprint(sb) {
+			t.Fatalf("spin %d differs between sources with the same seed", i)
+		}
+	}
+	if winner, seq := SpinWheel(src, []float64{0, 5}); winner != 1 || seq[len(seq)-1] != 1 {
+		t.Errorf("SpinWheel with one segment on the wheel = %d, %v", winner, seq)
+	}
+
+	for _, w := range [][]float64{nil, {0, 0}, {1, -2}, {math.NaN()}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("SpinWheel(%v) did not panic", w)
+				}
+			}()
+			SpinWheel(src, w)
+		}()
+	}
+}
@@ -0,0 +1,85 @@
+package milkrandom
+
+import (
+	"math"
+	"sort"
+)
+
+// GeoPoint generates a point uniformly distributed over the surface of a sphere, returned as
+// latitude in [-90, 90] and longitude in [-180, 180) degrees. Latitude is arcsine distributed,
+// so points are denser near the equator than a uniform draw in degrees would be.
+func GeoPoint(src Source) (latDeg, lonDeg float64) {
+	lat := math.Asin(2*float64From(src) - 1)
+	latDeg = lat * 180 / math.Pi
+	lonDeg = 360*float64From(src) - 180
+	return latDeg, lonDeg
+}
+
+// UniformSimplex generates a point uniformly distributed on the (n-1)-simplex, that is n
+// non-negative values summing to 1. It sorts n-1 uniform values and returns the gaps between
+// consecutive values, with 0 and 1 as the outer bounds. It panics if n < 1.
+func UniformSimplex(src Source, n int) []float64 {
+	if n < 1 {
+		panic("milkrandom: argument to UniformSimplex is < 1")
+	}
+	cuts := make([]float64, n-1, n)
+	for i := range cuts {
+		cuts[i] = float64From(src)
+	}
+	sort.Float64s(cuts)
+	cuts = append(cuts, 1)
+	point := make([]float64, n)
+	prev := 0.0
+	for i, c := range cuts {
+		point[i] = c - prev
+		prev = c
+	}
+	return point
+}
+
+// UnitQuaternion generates a uniformly random rotation as a unit quaternion [w, x, y, z]
+// using Shoemake's method with three uniform values.
+func UnitQuaternion(src Source) [4]float64 {
+	u1, u2, u3 := float64From(src), float64From(src), float64From(src)
+	a, b := math.Sqrt(1-u1), math.Sqrt(u1)
+	s2, c2 := math.Sincos(2 * math.Pi * u2)
+	s3, c3 := math.Sincos(2 * math.Pi * u3)
+	return [4]float64{b * c3, a * s2, a * c2, b * s3}
+}
+
+// RandomRotationMatrix generates a uniformly random 3×3 rotation matrix.
+// It is the matrix form of a quaternion drawn by UnitQuaternion.
+func RandomRotationMatrix(src Source) [3][3]float64 {
+	q := UnitQuaternion(src)
+	w, x, y, z := q[0], q[1], q[2], q[3]
+	return [3][3]float64{
+		{1 - 2*(y*y+z*z), 2 * (x*y - z*w), 2 * (x*z + y*w)},
+		{2 * (x*y + z*w), 1 - 2*(x*x+z*z), 2 * (y*z - x*w)},
+		{2 * (x*z - y*w), 2 * (y*z + x*w), 1 - 2*(x*x+y*y)},
+	}
+}
+
+// Sign returns -1 or +1 with equal probability.
+func Sign(src Source) int {
+	if src.Uint64()>>63 == 0 {
+		return -1
+	}
+	return 1
+}
+
+// directions2D lists the four orthogonal grid directions followed by the four diagonal ones.
+var directions2D = [8][2]int{
+	{0, -1}, {1, 0}, {0, 1}, {-1, 0},
+	{1, -1}, {1, 1}, {-1, 1}, {-1, -1},
+}
+
+// Direction2D returns one of the four orthogonal grid directions (dx, dy) uniformly at random.
+// If diagonal is true, it chooses among all eight neighbouring directions instead.
+func Direction2D(src Source, diagonal bool) (dx, dy int) {
+	n := 4
+	if diagonal {
+		n = 8
+	}
+	d := directions2D[src.Uint64()>>61&uint64(n-1)]
+	return d[0], d[1]
+}
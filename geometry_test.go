@@ -0,0 +1,179 @@
+package milkrandom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGeoPointLatitudeDensity(t *testing.T) {
+	src := newTestSource(1)
+	const n = 100000
+	bands := []float64{15, 30, 45, 60, 75, 90}
+	counts := make([]int, len(bands))
+	for i := 0; i < n; i++ {
+		lat, lon := GeoPoint(src)
+		if lat < -90 || lat > 90 || lon < -180 || lon >= 180 {
+			t.Fatalf("GeoPoint() = (%v, %v), out of range", lat, lon)
+		}
+		for j, b := range bands {
+			if math.Abs(lat) < b {
+				counts[j]++
+				break
+			}
+		}
+	}
+	// On a sphere the fraction of area with |latitude| < b is sin(b).
+	prev := 0.0
+	for j, b := range bands {
+		want := math.Sin(b*math.Pi/180) - prev
+		prev += want
+		if got := float64(counts[j]) / n; math.Abs(got-want) > 0.01 {
+			t.Errorf("band below %v degrees holds %v of points, want %v", b, got, want)
+		}
+	}
+}
+
+func TestUniformSimplex(t *testing.T) {
+	src := newTestSource(1)
+	const n, draws = 5, 50000
+	means := make([]float64, n)
+	for i := 0; i < draws; i++ {
+		p := UniformSimplex(src, n)
+		if len(p) != n {
+			t.Fatalf("len = %d, want %d", len(p), n)
+		}
+		sum := 0.0
+		for j, v := range p {
+			if v < 0 {
+				t.Fatalf("component %d = %v, negative", j, v)
+			}
+			sum += v
+			means[j] += v / draws
+		}
+		if math.Abs(sum-1) > 1e-12 {
+			t.Fatalf("components sum to %v, want 1", sum)
+		}
+	}
+	for j, m := range means {
+		if math.Abs(m-1.0/n) > 0.005 {
+			t.Errorf("mean of component %d = %v, want %v", j, m, 1.0/n)
+		}
+	}
+	if p := UniformSimplex(src, 1); len(p) != 1 || p[0] != 1 {
+		t.Errorf("UniformSimplex(1) = %v, want [1]", p)
+	}
+}
+
+func TestUnitQuaternion(t *testing.T) {
+	src := newTestSource(1)
+	for i := 0; i < 10000; i++ {
+		q := UnitQuaternion(src)
+		if norm := q[0]*q[0] + q[1]*q[1] + q[2]*q[2] + q[3]*q[3]; math.Abs(norm-1) > 1e-12 {
+			t.Fatalf("|q|^2 = %v, want 1", norm)
+		}
+	}
+}
+
+func TestRandomRotationMatrixUniform(t *testing.T) {
+	src := newTestSource(1)
+	const n = 60000
+	// A uniform rotation maps the z axis to a uniform point on the sphere, whose
+	// coordinates are each uniform on [-1, 1] (Archimedes' hat-box theorem).
+	bins := make([][4]int, 3)
+	for i := 0; i < n; i++ {
+		m := RandomRotationMatrix(src)
+		for r := 0; r < 3; r++ {
+			for c := 0; c < 3; c++ {
+				dot := 0.0
+				for k := 0; k < 3; k++ {
+					dot += m[r][k] * m[c][k]
+				}
+				want := 0.0
+				if r == c {
+					want = 1
+				}
+				if math.Abs(dot-want) > 1e-9 {
+					t.Fatalf("rotation matrix %v is not orthonormal", m)
+				}
+			}
+		}
+		for axis := 0; axis < 3; axis++ {
+			v := m[axis][2]
+			b := int((v + 1) * 2)
+			if b == 4 {
+				b = 3
+			}
+			bins[axis][b]++
+		}
+	}
+	for axis, counts := range bins {
+		for b, c := range counts {
+			if math.Abs(float64(c)-n/4) > 0.03*n/4 {
+				t.Errorf("coordinate %d of the rotated z axis fell in quarter %d %d times, want about %d", axis, b, c, n/4)
+			}
+		}
+	}
+}
+
+func TestSign(t *testing.T) {
+	src := newTestSource(1)
+	const n = 100000
+	sum := 0
+	for i := 0; i < n; i++ {
+		s := Sign(src)
+		if s != -1 && s != 1 {
+			t.Fatalf("Sign() = %d, want -1 or 1", s)
+		}
+		sum += s
+	}
+	// The sum of n fair signs has standard deviation sqrt(n), about 316.
+	if sum < -1500 || sum > 1500 {
+		t.Errorf("sum of %d signs = %d, want close to 0", n, sum)
+	}
+
+	a, b := newTestSource(7), newTestSource(7)
+	for i := 0; i < 100; i++ {
+		if Sign(a) != Sign(b) {
+			t.Fatalf("Sign %d differs between sources with the same seed", i)
+		}
+	}
+}
+
+func TestDirection2D(t *testing.T) {
+	for _, diagonal := range []bool{false, true} {
+		src := newTestSource(1)
+		const n = 80000
+		counts := make(map[[2]int]int)
+		for i := 0; i < n; i++ {
+			dx, dy := Direction2D(src, diagonal)
+			if dx < -1 || dx > 1 || dy < -1 || dy > 1 || dx == 0 && dy == 0 {
+				t.Fatalf("Direction2D(%v) = (%d, %d), not a grid direction", diagonal, dx, dy)
+			}
+			if !diagonal && dx != 0 && dy != 0 {
+				t.Fatalf("Direction2D(false) = (%d, %d), a diagonal direction", dx, dy)
+			}
+			counts[[2]int{dx, dy}]++
+		}
+		want := 4
+		if diagonal {
+			want = 8
+		}
+		if len(counts) != want {
+			t.Errorf("Direction2D(%v) returned %d distinct directions, want %d", diagonal, len(counts), want)
+		}
+		for d, c := range counts {
+			if p := float64(c) / n; math.Abs(p-1/float64(want)) > 0.01 {
+				t.Errorf("Direction2D(%v) returned %v with frequency %v, want %v", diagonal, d, p, 1/float64(want))
+			}
+		}
+
+		a, b := newTestSource(7), newTestSource(7)
+		for i := 0; i < 100; i++ {
+			ax, ay := Direction2D(a, diagonal)
+			bx, by := Direction2D(b, diagonal)
+			if ax != bx || ay != by {
+				t.Fatalf("Direction2D %d differs between sources with the same seed", i)
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package milkrandom
+
+// ErdosRenyi generates a random undirected G(n, p) graph on n vertices, where each of the
+// n(n-1)/2 possible edges is present independently with probability p. It returns the adjacency
+// lists of the vertices. Candidate edges are visited with geometric skips (the Batagelj–Brandes
+// method), so sparse graphs cost time proportional to the number of edges rather than n².
+// It panics if n < 0 or p is not in [0, 1].
+func ErdosRenyi(src Source, n int, p float64) [][]int {
+	if n < 0 {
+		panic("milkrandom: argument n to ErdosRenyi is < 0")
+	}
+	if !(p >= 0 && p <= 1) {
+		panic("milkrandom: argument p to ErdosRenyi is not in [0, 1]")
+	}
+	adj := make([][]int, n)
+	if p == 0 {
+		return adj
+	}
+	v, w := 1, -1
+	for v < n {
+		skip := NextSkip(src, p)
+		if skip >= int64(n)*int64(n) { // past the last candidate edge
+			break
+		}
+		w += 1 + int(skip)
+		for w >= v && v < n {
+			w -= v
+			v++
+		}
+		if v < n {
+			adj[v] = append(adj[v], w)
+			adj[w] = append(adj[w], v)
+		}
+	}
+	return adj
+}
+
+// RandomTree generates a uniformly random labeled tree on n vertices and returns it as a parent
+// array rooted at vertex n-1: parent[n-1] is -1 and every other entry is the parent of that vertex.
+// It draws a random Prüfer sequence of length n-2 and decodes it in linear time; since Prüfer
+// sequences correspond one-to-one with labeled trees, all n^(n-2) trees are equally likely.
+// It panics if n < 1.
+func RandomTree(src Source, n int) []int {
+	if n < 1 {
+		panic("milkrandom: argument to RandomTree is < 1")
+	}
+	parent := make([]int, n)
+	parent[n-1] = -1
+	if n == 1 {
+		return parent
+	}
+	code := make([]int, n-2)
+	degree := make([]int, n)
+	for i := range degree {
+		degree[i] = 1
+	}
+	for i := range code {
+		code[i] = intn(src, n)
+		degree[code[i]]++
+	}
+	ptr := 0
+	for degree[ptr] != 1 {
+		ptr++
+	}
+	leaf := ptr
+	for _, v := range code {
+		parent[leaf] = v
+		degree[v]--
+		if degree[v] == 1 && v < ptr {
+			leaf = v
+		} else {
+			ptr++
+			for degree[ptr] != 1 {
+				ptr++
+			}
+			leaf = ptr
+		}
+	}
+	parent[leaf] = n - 1
+	return parent
+}
@@ -0,0 +1,128 @@
+package milkrandom
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestErdosRenyiEdgeCount(t *testing.T) {
+	const n, trials = 100, 200
+	pairs := float64(n * (n - 1) / 2)
+	for _, p := range []float64{0, 0.01, 0.1, 0.5, 1} {
+		src := newTestSource(1)
+		total := 0
+		for trial := 0; trial < trials; trial++ {
+			adj := ErdosRenyi(src, n, p)
+			if len(adj) != n {
+				t.Fatalf("ErdosRenyi(%d, %v) has %d vertices", n, p, len(adj))
+			}
+			edges := make(map[[2]int]bool)
+			for v, ns := range adj {
+				for _, w := range ns {
+					if w == v || w < 0 || w >= n {
+						t.Fatalf("ErdosRenyi(%d, %v) has an invalid edge %d-%d", n, p, v, w)
+					}
+					edges[[2]int{v, w}] = true
+				}
+			}
+			for e := range edges {
+				if !edges[[2]int{e[1], e[0]}] {
+					t.Fatalf("ErdosRenyi(%d, %v) has edge %d-%d in one direction only", n, p, e[0], e[1])
+				}
+			}
+			degrees := 0
+			for _, ns := range adj {
+				degrees += len(ns)
+			}
+			if degrees != len(edges) {
+				t.Fatalf("ErdosRenyi(%d, %v) lists an edge twice", n, p)
+			}
+			total += len(edges) / 2
+		}
+		want := p * pairs
+		sd := math.Sqrt(pairs * p * (1 - p) / trials)
+		if got := float64(total) / trials; math.Abs(got-want) > 4*sd+1e-9 {
+			t.Errorf("ErdosRenyi(%d, %v) has %v edges on average, want %v", n, p, got, want)
+		}
+	}
+
+	a := ErdosRenyi(newTestSource(7), 50, 0.2)
+	b := ErdosRenyi(newTestSource(7), 50, 0.2)
+	for v := range a {
+		if len(a[v]) != len(b[v]) {
+			t.Fatalf("vertex %d has %d and %d neighbours for sources with the same seed", v, len(a[v]), len(b[v]))
+		}
+		for i := range a[v] {
+			if a[v][i] != b[v][i] {
+				t.Fatalf("vertex %d has different neighbours for sources with the same seed", v)
+			}
+		}
+	}
+}
+
+// checkTree reports whether parent is a tree rooted at len(parent)-1: every other vertex has a
+// parent, and following parents from any vertex reaches the root without revisiting a vertex.
+func checkTree(parent []int) error {
+	n := len(parent)
+	if parent[n-1] != -1 {
+		return fmt.Errorf("root %d has parent %d", n-1, parent[n-1])
+	}
+	for v := 0; v < n-1; v++ {
+		if p := parent[v]; p < 0 || p >= n || p == v {
+			return fmt.Errorf("vertex %d has parent %d", v, p)
+		}
+		u, steps := v, 0
+		for u != n-1 {
+			if steps++; steps >= n {
+				return fmt.Errorf("vertex %d is on a cycle", v)
+			}
+			u = parent[u]
+		}
+	}
+	return nil
+}
+
+func TestRandomTree(t *testing.T) {
+	src := newTestSource(1)
+	for _, n := range []int{1, 2, 3, 10, 500} {
+		for trial := 0; trial < 20; trial++ {
+			parent := RandomTree(src, n)
+			if len(parent) != n {
+				t.Fatalf("RandomTree(%d) returned %d entries", n, len(parent))
+			}
+			// n-1 parent links that reach the root from every vertex form a spanning tree.
+			if err := checkTree(parent); err != nil {
+				t.Fatalf("RandomTree(%d) = %v: %v", n, parent, err)
+			}
+		}
+	}
+
+	// All 4^2 = 16 labeled trees on 4 vertices are equally likely.
+	const trials = 80000
+	counts := make(map[string]int)
+	for i := 0; i < trials; i++ {
+		counts[fmt.Sprint(RandomTree(src, 4))]++
+	}
+	if len(counts) != 16 {
+		t.Fatalf("RandomTree(4) produced %d distinct trees, want 16", len(counts))
+	}
+	for tree, c := range counts {
+		if p := float64(c) / trials; math.Abs(p-1.0/16) > 0.005 {
+			t.Errorf("tree %s has frequency %v, want %v", tree, p, 1.0/16)
+		}
+	}
+
+	a := RandomTree(newTestSource(7), 100)
+	b := RandomTree(newTestSource(7), 100)
+	if fmt.Sprint(a) != fmt.Sprint(b) {
+		t.Error("RandomTree differs between sources with the same seed")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("RandomTree(0) did not panic")
+		}
+	}()
+	RandomTree(src, 0)
+}
@@ -0,0 +1,55 @@
+package milkrandom
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// golden is the SplitMix64 increment, used to separate successive words when mixing.
+const golden = 0x9e3779b97f4a7c15
+
+// ValueNoise2D returns a reproducible value in the range [0.0, 1.0) for the integer
+// coordinates (x, y) under the given seed. Unlike the stream generators it is stateless:
+// the same seed and coordinates always yield the same value regardless of call order.
+func ValueNoise2D(seed uint64, x, y int) float64 {
+	h := mix64(seed + golden)
+	h = mix64((h ^ uint64(x)) + golden)
+	h = mix64((h ^ uint64(y)) + golden)
+	return float64(h>>(64-53)) / (1 << 53)
+}
+
+// hashKey hashes key under seed by folding its bytes, eight at a time, through SplitMix64 mixing.
+func hashKey(seed uint64, key string) uint64 {
+	n := len(key)
+	h := mix64(seed + golden)
+	for len(key) >= 8 {
+		h = mix64((h ^ binary.LittleEndian.Uint64([]byte(key[:8]))) + golden)
+		key = key[8:]
+	}
+	var tail [8]byte
+	copy(tail[:], key)
+	h = mix64((h ^ binary.LittleEndian.Uint64(tail[:])) + golden)
+	return mix64((h ^ uint64(n)) + golden)
+}
+
+// HashJitter deterministically maps key to a bucket in [0, buckets), salted by seed.
+// It is stateless: the same key and seed always map to the same bucket, while a different
+// seed redistributes keys independently. It panics if buckets <= 0.
+func HashJitter(seed uint64, key string, buckets int) int {
+	if buckets <= 0 {
+		panic("milkrandom: argument buckets to HashJitter is <= 0")
+	}
+	hi, _ := bits.Mul64(hashKey(seed, key), uint64(buckets))
+	return int(hi)
+}
+
+// InSample reports whether key falls in a deterministic sample containing the given fraction of
+// all keys, salted by seed. It is stateless, which suits A/B bucketing by user ID: the same key
+// and seed always give the same answer, and raising fraction only ever adds keys to the sample.
+// It panics if fraction is not in [0, 1].
+func InSample(seed uint64, key string, fraction float64) bool {
+	if !(fraction >= 0 && fraction <= 1) {
+		panic("milkrandom: argument fraction to InSample is not in [0, 1]")
+	}
+	return float64(hashKey(seed, key)>>(64-53))/(1<<53) < fraction
+}
@@ -0,0 +1,107 @@
+package milkrandom
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestValueNoise2D(t *testing.T) {
+	differ := 0
+	for x := -20; x < 20; x++ {
+		for y := -20; y < 20; y++ {
+			v := ValueNoise2D(7, x, y)
+			if v < 0 || v >= 1 {
+				t.Fatalf("ValueNoise2D(7, %d, %d) = %v, outside [0, 1)", x, y, v)
+			}
+			if again := ValueNoise2D(7, x, y); again != v {
+				t.Fatalf("ValueNoise2D(7, %d, %d) returned %v then %v", x, y, v, again)
+			}
+			if ValueNoise2D(8, x, y) != v {
+				differ++
+			}
+		}
+	}
+	if differ < 1590 {
+		t.Errorf("changing the seed changed only %d of 1600 values", differ)
+	}
+	if ValueNoise2D(7, 1, 2) == ValueNoise2D(7, 2, 1) {
+		t.Error("ValueNoise2D is symmetric in x and y")
+	}
+}
+
+func TestHashJitter(t *testing.T) {
+	const keys, buckets = 20000, 16
+	counts := make([]int, buckets)
+	moved := 0
+	for i := 0; i < keys; i++ {
+		key := "user-" + strconv.Itoa(i)
+		b := HashJitter(1, key, buckets)
+		if b < 0 || b >= buckets {
+			t.Fatalf("HashJitter(1, %q, %d) = %d, out of range", key, buckets, b)
+		}
+		if again := HashJitter(1, key, buckets); again != b {
+			t.Fatalf("HashJitter(1, %q, %d) = %d, then %d", key, buckets, b, again)
+		}
+		counts[HashJitter(2, key, buckets)]++
+		if HashJitter(2, key, buckets) != b {
+			moved++
+		}
+	}
+	// Under an independent seed a key stays in its bucket with probability 1/buckets.
+	if want := keys * (buckets - 1) / buckets; moved < want-400 || moved > want+400 {
+		t.Errorf("changing the seed moved %d of %d keys, want about %d", moved, keys, want)
+	}
+	for b, c := range counts {
+		if c < keys/buckets-250 || c > keys/buckets+250 {
+			t.Errorf("bucket %d received %d of %d keys, want about %d", b, c, keys, keys/buckets)
+		}
+	}
+}
+
+func TestInSample(t *testing.T) {
+	const keys = 20000
+	for _, fraction := range []float64{0, 0.1, 0.5, 1} {
+		in := 0
+		for i := 0; i < keys; i++ {
+			key := "user-" + strconv.Itoa(i)
+			got := InSample(1, key, fraction)
+			if InSample(1, key, fraction) != got {
+				t.Fatalf("InSample(1, %q, %v) is not stable", key, fraction)
+			}
+			// Raising the fraction only adds keys.
+			if got && !InSample(1, key, math.Min(1, fraction+0.2)) {
+				t.Fatalf("key %q is in the %v sample but not in a larger one", key, fraction)
+			}
+			if got {
+				in++
+			}
+		}
+		if f := float64(in) / keys; math.Abs(f-fraction) > 0.01 {
+			t.Errorf("InSample(%v) selected %v of keys", fraction, f)
+		}
+	}
+
+	// A different seed selects a different half of the keys.
+	same := 0
+	for i := 0; i < keys; i++ {
+		key := "user-" + strconv.Itoa(i)
+		if InSample(1, key, 0.5) == InSample(2, key, 0.5) {
+			same++
+		}
+	}
+	if f := float64(same) / keys; math.Abs(f-0.5) > 0.02 {
+		t.Errorf("seeds 1 and 2 agree on %v of keys, want about 0.5", f)
+	}
+
+	for _, fraction := range []float64{-0.1, 1.1, math.NaN()} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("InSample with fraction %v did not panic", fraction)
+				}
+			}()
+			InSample(1, "key", fraction)
+		}()
+	}
+}
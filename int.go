@@ -0,0 +1,88 @@
+package milkrandom
+
+import "errors"
+
+// MaxStuckRetries is the number of consecutive identical rejected values after which
+// TryInt gives up. A working generator repeats a rejected value this many times in a row
+// with negligible probability, so reaching the limit indicates a broken or stuck source.
+const MaxStuckRetries = 1024
+
+// ErrStuckSource is returned by TryInt when the source keeps returning the same rejected value.
+var ErrStuckSource = errors.New("milkrandom: source repeatedly returned the same rejected value")
+
+// TryInt generates a random integer in the range [0, n) from src. Unlike the Int methods
+// of the generators, it returns an error instead of panicking when n <= 0, and returns
+// ErrStuckSource instead of looping forever when src returns the same rejected value
+// MaxStuckRetries times in a row.
+func TryInt(src Source, n int) (int, error) {
+	if n <= 0 {
+		return 0, errors.New("milkrandom: argument to TryInt is <= 0")
+	}
+	if n&(n-1) == 0 { // n is 2^m, use mask
+		return int(src.Uint64() & uint64(n-1)), nil
+	}
+	threshold := -uint64(n) % uint64(n) // 2^64 mod n, as in uint64n
+	v := src.Uint64()
+	stuck := 0
+	for v < threshold {
+		prev := v
+		v = src.Uint64()
+		if v != prev {
+			stuck = 0
+			continue
+		}
+		stuck++
+		if stuck >= MaxStuckRetries {
+			return 0, ErrStuckSource
+		}
+	}
+	return int(v % uint64(n)), nil
+}
+
+// IntDiv generates a random index in the range [0, n) and returns it decomposed as if
+// the n items were dealt round-robin into the given number of groups: group is the
+// index modulo groups and within is the position of the item inside its group.
+func IntDiv(src Source, n, groups int) (group, within int) {
+	if n <= 0 {
+		panic("milkrandom: argument n to IntDiv is <= 0")
+	}
+	if groups <= 0 {
+		panic("milkrandom: argument groups to IntDiv is <= 0")
+	}
+	i := intn(src, n)
+	return i % groups, i / groups
+}
+
+// intn generates a random integer in the range [0, n) from src. It panics if n <= 0.
+func intn(src Source, n int) int {
+	if n <= 0 {
+		panic("milkrandom: argument to Int is <= 0")
+	}
+	return int(uint64n(src, uint64(n)))
+}
+
+// ExpectedRejectionRate returns the probability that a single 64-bit draw is rejected when
+// generating a uniform integer in [0, n) by rejection, (2^64 mod n) / 2^64. It is computed in
+// closed form without sampling, so the expected number of draws per value, 1 / (1 - rate),
+// can be judged before choosing n. It is 0 for powers of two. It describes the helpers in this
+// package, not the generators' own Int methods; pcg32's Int, for one, rejects 32-bit draws at a
+// rate of (2^32 mod n) / 2^32 for n <= 2^31. It panics if n == 0.
+func ExpectedRejectionRate(n uint64) float64 {
+	if n == 0 {
+		panic("milkrandom: argument to ExpectedRejectionRate is 0")
+	}
+	return float64(-n%n) / (1 << 64)
+}
+
+// uint64n generates a random integer in the range [0, n) from src for any n > 0.
+func uint64n(src Source, n uint64) uint64 {
+	if n&(n-1) == 0 { // n is 2^m, use mask
+		return src.Uint64() & (n - 1)
+	}
+	threshold := -n % n // 2^64 mod n
+	v := src.Uint64()
+	for v < threshold {
+		v = src.Uint64()
+	}
+	return v % n
+}
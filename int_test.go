@@ -0,0 +1,130 @@
+package milkrandom
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// constSource is a broken Source that always returns the same value.
+type constSource uint64
+
+func (c constSource) Uint64() uint64 { return uint64(c) }
+
+func TestTryIntStuckSource(t *testing.T) {
+	done := make(chan error, 1)
+	go func() {
+		// 0 is below 2^64 mod 3 = 1, so it is always rejected.
+		_, err := TryInt(constSource(0), 3)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != ErrStuckSource {
+			t.Errorf("TryInt on a stuck source returned %v, want ErrStuckSource", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("TryInt on a stuck source did not return")
+	}
+}
+
+func TestTryInt(t *testing.T) {
+	src := newTestSource(1)
+	for _, n := range []int{1, 3, 8, 1000} {
+		for i := 0; i < 1000; i++ {
+			v, err := TryInt(src, n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v < 0 || v >= n {
+				t.Fatalf("TryInt(%d) = %d, out of range", n, v)
+			}
+		}
+	}
+	if _, err := TryInt(src, 0); err == nil {
+		t.Error("TryInt(0) returned no error")
+	}
+}
+
+func TestIntDivJointUniform(t *testing.T) {
+	src := newTestSource(1)
+	const n, groups, draws = 12, 5, 120000
+	counts := make(map[[2]int]int)
+	for i := 0; i < draws; i++ {
+		g, w := IntDiv(src, n, groups)
+		if idx := w*groups + g; g < 0 || g >= groups || idx < 0 || idx >= n {
+			t.Fatalf("IntDiv(%d, %d) = (%d, %d), not a valid index", n, groups, g, w)
+		}
+		counts[[2]int{g, w}]++
+	}
+	if len(counts) != n {
+		t.Fatalf("IntDiv produced %d distinct outcomes, want %d", len(counts), n)
+	}
+	want := float64(draws) / n
+	for k, c := range counts {
+		if math.Abs(float64(c)-want) > 0.05*want {
+			t.Errorf("outcome %v drawn %d times, want about %v", k, c, want)
+		}
+	}
+}
+
+// seqSource is a Source that returns its values in order.
+type seqSource []uint64
+
+func (s *seqSource) Uint64() uint64 {
+	v := (*s)[0]
+	*s = (*s)[1:]
+	return v
+}
+
+func TestExpectedRejectionRate(t *testing.T) {
+	for _, n := range []uint64{1, 2, 8, 1 << 20, 1 << 63} {
+		if got := ExpectedRejectionRate(n); got != 0 {
+			t.Errorf("ExpectedRejectionRate(%d) = %v, want 0", n, got)
+		}
+	}
+	for _, c := range []struct {
+		n    uint64
+		want float64
+	}{
+		{3, 1.0 / (1 << 64)},  // 2^64 = 3 * 6148914691236517205 + 1
+		{10, 6.0 / (1 << 64)}, // 2^64 = 18446744073709551616
+		{1000, 616.0 / (1 << 64)},
+		{1<<63 + 1, float64(1<<63-1) / (1 << 64)}, // 2^64 = (2^63+1) + (2^63-1)
+		{^uint64(0), 1.0 / (1 << 64)},
+	} {
+		if got := ExpectedRejectionRate(c.n); got != c.want {
+			t.Errorf("ExpectedRejectionRate(%d) = %v, want %v", c.n, got, c.want)
+		}
+	}
+}
+
+// TestBoundedSamplersShareThreshold checks that intn, TryInt and uint64n reject exactly
+// the draws below 2^64 mod n, the values ExpectedRejectionRate counts.
+func TestBoundedSamplersShareThreshold(t *testing.T) {
+	const n = 10 // 2^64 mod 10 = 6
+	samplers := map[string]func(Source) uint64{
+		"intn":    func(src Source) uint64 { return uint64(intn(src, n)) },
+		"uint64n": func(src Source) uint64 { return uint64n(src, n) },
+		"TryInt": func(src Source) uint64 {
+			v, err := TryInt(src, n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return uint64(v)
+		},
+	}
+	for name, sample := range samplers {
+		src := seqSource{0, 5, 6, 17}
+		if got := sample(&src); got != 6 {
+			t.Errorf("%s on draws 0, 5, 6 = %d, want 6 after rejecting 0 and 5", name, got)
+		}
+		if got := sample(&src); got != 7 {
+			t.Errorf("%s on draw 17 = %d, want 7", name, got)
+		}
+		src = seqSource{^uint64(0)}
+		if got := sample(&src); got != ^uint64(0)%n {
+			t.Errorf("%s on draw 2^64-1 = %d, want %d", name, got, ^uint64(0)%n)
+		}
+	}
+}
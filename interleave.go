@@ -0,0 +1,117 @@
+package milkrandom
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// seeder is implemented by sources that can be reseeded.
+type seeder interface {
+	Seed(seed uint64)
+}
+
+// stateMarshaler is implemented by sources whose state can be saved and restored.
+type stateMarshaler interface {
+	Marshal() ([]byte, error)
+	Unmarshal(data []byte) error
+}
+
+// Interleaved is a Source that alternates between two underlying sources,
+// returning values from a and b in turn, starting with a.
+type Interleaved struct {
+	a, b  Source
+	nextB bool
+}
+
+// Interleave creates a new Interleaved source that alternates between a and b.
+// Unlike combining the outputs, every value of each stream is preserved unchanged.
+func Interleave(a, b Source) *Interleaved {
+	return &Interleaved{a: a, b: b}
+}
+
+// Uint64 returns the next value from a or b, alternating on every call.
+func (s *Interleaved) Uint64() uint64 {
+	if s.nextB {
+		s.nextB = false
+		return s.b.Uint64()
+	}
+	s.nextB = true
+	return s.a.Uint64()
+}
+
+// Seed seeds a with seed and b with a value derived from seed, so the two streams differ
+// even if a and b are the same kind of generator. The next value is taken from a.
+// It panics if either source does not have a Seed(uint64) method.
+func (s *Interleaved) Seed(seed uint64) {
+	a, okA := s.a.(seeder)
+	b, okB := s.b.(seeder)
+	if !okA || !okB {
+		panic("milkrandom: interleaved source does not support Seed")
+	}
+	a.Seed(seed)
+	b.Seed(mix64(seed + golden))
+	s.nextB = false
+}
+
+// Marshal returns the binary encoding of the state of both sources and whose turn is next.
+func (s *Interleaved) Marshal() ([]byte, error) {
+	a, okA := s.a.(stateMarshaler)
+	b, okB := s.b.(stateMarshaler)
+	if !okA || !okB {
+		return nil, errors.New("milkrandom: interleaved source does not support Marshal")
+	}
+	stateA, err := a.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	stateB, err := b.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 5, 5+len(stateA)+len(stateB))
+	if s.nextB {
+		buf[0] = 1
+	}
+	binary.LittleEndian.PutUint32(buf[1:], uint32(len(stateA)))
+	buf = append(buf, stateA...)
+	return append(buf, stateB...), nil
+}
+
+// Unmarshal sets the state of both sources and whose turn is next from data produced by Marshal.
+// If either half of data is rejected, both sources are left in their previous state. If putting the
+// first source back fails too, the returned error wraps both errors and that source may be left
+// partially restored.
+func (s *Interleaved) Unmarshal(data []byte) error {
+	a, okA := s.a.(stateMarshaler)
+	b, okB := s.b.(stateMarshaler)
+	if !okA || !okB {
+		return errors.New("milkrandom: interleaved source does not support Unmarshal")
+	}
+	if len(data) < 5 || data[0] > 1 {
+		return errors.New("milkrandom: invalid interleaved state")
+	}
+	n := binary.LittleEndian.Uint32(data[1:])
+	if uint64(n) > uint64(len(data)-5) {
+		return errors.New("milkrandom: invalid interleaved state")
+	}
+	// Save a first so it can be put back if either half turns out to be invalid.
+	saved, err := a.Marshal()
+	if err != nil {
+		return err
+	}
+	restore := func(err error) error {
+		if rerr := a.Unmarshal(saved); rerr != nil {
+			return fmt.Errorf("%w; restoring the previous interleaved state also failed: %w", err, rerr)
+		}
+		return err
+	}
+	if err := a.Unmarshal(data[5 : 5+n]); err != nil {
+		return restore(err)
+	}
+	if err := b.Unmarshal(data[5+n:]); err != nil {
+		return restore(err)
+	}
+	s.nextB = data[0] == 1
+	return nil
+}
@@ -0,0 +1,106 @@
+package milkrandom
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/MilkLua/milkrandom/pcg32"
+	"github.com/MilkLua/milkrandom/splitmix64"
+)
+
+func TestInterleaveAlternates(t *testing.T) {
+	s := Interleave(newTestSource(1), newTestSource(2))
+	a, b := newTestSource(1), newTestSource(2)
+	for i := 0; i < 100; i++ {
+		want := a
+		if i%2 == 1 {
+			want = b
+		}
+		if got, w := s.Uint64(), want.Uint64(); got != w {
+			t.Fatalf("output %d = %#x, want %#x", i, got, w)
+		}
+	}
+}
+
+func TestInterleaveReproducible(t *testing.T) {
+	s1 := Interleave(&splitmix64.SplitMix64{}, &pcg32.PCG32{})
+	s2 := Interleave(&splitmix64.SplitMix64{}, &pcg32.PCG32{})
+	s1.Seed(9)
+	s2.Seed(9)
+	s1.Uint64()
+	state, err := s1.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s2.Uint64()
+	for i := 0; i < 100; i++ {
+		if x, y := s1.Uint64(), s2.Uint64(); x != y {
+			t.Fatalf("output %d differs after seeding both with 9: %#x != %#x", i, x, y)
+		}
+	}
+	if err := s2.Unmarshal(state); err != nil {
+		t.Fatal(err)
+	}
+	s1.Unmarshal(state)
+	for i := 0; i < 100; i++ {
+		if x, y := s1.Uint64(), s2.Uint64(); x != y {
+			t.Fatalf("output %d differs after restoring: %#x != %#x", i, x, y)
+		}
+	}
+}
+
+func TestInterleaveUnmarshalInvalidLeavesState(t *testing.T) {
+	s := Interleave(&splitmix64.SplitMix64{}, &pcg32.PCG32{})
+	s.Seed(1)
+	before, _ := s.Marshal()
+
+	other := Interleave(&splitmix64.SplitMix64{}, &pcg32.PCG32{})
+	other.Seed(2)
+	valid, _ := other.Marshal()
+	// Keep the valid first half and truncate the second.
+	if err := s.Unmarshal(valid[:len(valid)-1]); err == nil {
+		t.Fatal("Unmarshal accepted a truncated state")
+	}
+	if after, _ := s.Marshal(); !bytes.Equal(before, after) {
+		t.Error("failed Unmarshal changed the state")
+	}
+}
+
+// flakyState is a SplitMix64 whose Unmarshal fails with err once it has succeeded ok times.
+type flakyState struct {
+	splitmix64.SplitMix64
+	ok  int
+	err error
+}
+
+func (f *flakyState) Unmarshal(data []byte) error {
+	if f.ok == 0 {
+		return f.err
+	}
+	f.ok--
+	return f.SplitMix64.Unmarshal(data)
+}
+
+func TestInterleaveUnmarshalRestoreError(t *testing.T) {
+	errLoad, errRestore := errors.New("load failed"), errors.New("restore failed")
+	// The first source accepts the new state but not its saved state, and the second rejects its half.
+	s := Interleave(&flakyState{ok: 1, err: errRestore}, &flakyState{err: errLoad})
+	s.Seed(1)
+	data, err := s.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Unmarshal(data)
+	if !errors.Is(err, errLoad) || !errors.Is(err, errRestore) {
+		t.Errorf("Unmarshal = %v, want an error wrapping both %v and %v", err, errLoad, errRestore)
+	}
+
+	// When restoring succeeds, the second source's error is returned as is.
+	s = Interleave(&flakyState{ok: 2, err: errRestore}, &flakyState{err: errLoad})
+	s.Seed(1)
+	data, _ = s.Marshal()
+	if err := s.Unmarshal(data); err != errLoad {
+		t.Errorf("Unmarshal = %v, want %v", err, errLoad)
+	}
+}
@@ -0,0 +1,61 @@
+package milkrandom
+
+import "errors"
+
+// WeightedNext is a possible successor of a state in a MarkovChain, with its weight relative to
+// the other successors of the same state.
+type WeightedNext struct {
+	State  int
+	Weight float64
+}
+
+// MarkovChain is a discrete-time Markov chain over integer states.
+type MarkovChain struct {
+	next map[int]markovRow
+}
+
+// markovRow holds the successors of one state and their cumulative weights.
+type markovRow struct {
+	states []int
+	cum    []float64
+}
+
+// NewMarkovChain creates a new MarkovChain from the successors of each state. Weights need not
+// sum to 1. It returns an error if a state has no successors or invalid weights, or if a
+// successor has no transitions of its own, since the chain could then not continue from it.
+func NewMarkovChain(transitions map[int][]WeightedNext) (*MarkovChain, error) {
+	m := &MarkovChain{next: make(map[int]markovRow, len(transitions))}
+	for state, succ := range transitions {
+		if len(succ) == 0 {
+			return nil, errors.New("milkrandom: every state of a Markov chain must have transitions")
+		}
+		row := markovRow{states: make([]int, len(succ))}
+		weights := make([]float64, len(succ))
+		for i, s := range succ {
+			if _, ok := transitions[s.State]; !ok {
+				return nil, errors.New("milkrandom: every successor in a Markov chain must have transitions")
+			}
+			row.states[i], weights[i] = s.State, s.Weight
+		}
+		cum, err := cumulativeWeights(weights)
+		if err != nil {
+			return nil, err
+		}
+		row.cum = cum
+		m.next[state] = row
+	}
+	if len(m.next) == 0 {
+		return nil, errors.New("milkrandom: Markov chain has no states")
+	}
+	return m, nil
+}
+
+// Next returns the state that follows state, chosen by weight using src.
+// It panics if state is not a state of the chain.
+func (m *MarkovChain) Next(src Source, state int) int {
+	row, ok := m.next[state]
+	if !ok {
+		panic("milkrandom: unknown state passed to MarkovChain.Next")
+	}
+	return row.states[pickCumulative(src, row.cum)]
+}
@@ -0,0 +1,54 @@
+package milkrandom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMarkovChainStationary(t *testing.T) {
+	// 0 stays or moves to 1 with equal weight, 1 always moves to 2 and 2 back to 0. Solving
+	// pi = pi P gives the stationary distribution (1/2, 1/4, 1/4).
+	m, err := NewMarkovChain(map[int][]WeightedNext{
+		0: {{State: 0, Weight: 2}, {State: 1, Weight: 2}},
+		1: {{State: 2, Weight: 1}},
+		2: {{State: 0, Weight: 5}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := newTestSource(1)
+	const n = 200000
+	counts := make(map[int]int)
+	state := 0
+	for i := 0; i < n; i++ {
+		state = m.Next(src, state)
+		counts[state]++
+	}
+	for s, want := range map[int]float64{0: 0.5, 1: 0.25, 2: 0.25} {
+		if got := float64(counts[s]) / n; math.Abs(got-want) > 0.01 {
+			t.Errorf("state %d visited with frequency %v, want %v", s, got, want)
+		}
+	}
+
+	a, b := newTestSource(7), newTestSource(7)
+	sa, sb := 0, 0
+	for i := 0; i < 100; i++ {
+		if sa, sb = m.Next(a, sa), m.Next(b, sb); sa != sb {
+			t.Fatalf("step %d differs between sources with the same seed", i)
+		}
+	}
+}
+
+func TestNewMarkovChainInvalid(t *testing.T) {
+	for name, transitions := range map[string]map[int][]WeightedNext{
+		"no states":          {},
+		"no successors":      {0: {{State: 1, Weight: 1}}, 1: {}},
+		"dangling successor": {0: {{State: 1, Weight: 1}}},
+		"negative weight":    {0: {{State: 0, Weight: -1}}},
+		"zero total":         {0: {{State: 0, Weight: 0}}},
+	} {
+		if _, err := NewMarkovChain(transitions); err == nil {
+			t.Errorf("NewMarkovChain accepted a chain with %s", name)
+		}
+	}
+}
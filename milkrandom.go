@@ -0,0 +1,27 @@
+// Package milkrandom provides generator-independent helpers built on top of the
+// random number generators in its subpackages.
+package milkrandom
+
+// Source is a source of uniformly distributed random 64-bit unsigned integers.
+// Every generator in this module implements Source.
+type Source interface {
+	Uint64() uint64
+}
+
+// float64From generates a random float64 in the range [0.0, 1.0) from src.
+func float64From(src Source) float64 {
+	return float64(src.Uint64()>>(64-53)) / (1 << 53)
+}
+
+// float64Open generates a random float64 in the open range (0.0, 1.0) from src.
+func float64Open(src Source) float64 {
+	return (float64(src.Uint64()>>(64-53)) + 0.5) / (1 << 53)
+}
+
+// mix64 is the SplitMix64 output function. It is a bijective mixer used to derive
+// stateless values from seeds and keys.
+func mix64(z uint64) uint64 {
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
@@ -0,0 +1,10 @@
+package milkrandom
+
+import "github.com/MilkLua/milkrandom/splitmix64"
+
+// newTestSource returns a deterministic Source for tests.
+func newTestSource(seed uint64) Source {
+	s := &splitmix64.SplitMix64{}
+	s.Seed(seed)
+	return s
+}
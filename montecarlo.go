@@ -0,0 +1,64 @@
+package milkrandom
+
+import "math"
+
+// AntitheticPairs returns n antithetic pairs (u, 1-u) with u uniform in [0, 1). Averaging a
+// monotone function over both halves of each pair is negatively correlated, which reduces the
+// variance of Monte Carlo estimates compared with 2n independent uniforms. It panics if n < 0.
+func AntitheticPairs(src Source, n int) [][2]float64 {
+	if n < 0 {
+		panic("milkrandom: argument to AntitheticPairs is < 0")
+	}
+	pairs := make([][2]float64, n)
+	for i := range pairs {
+		u := float64From(src)
+		pairs[i] = [2]float64{u, 1 - u}
+	}
+	return pairs
+}
+
+// Stratified returns n points in [0, 1), one drawn uniformly from each stratum [i/n, (i+1)/n),
+// in increasing order. Because every stratum is covered exactly once, averages over the points
+// have lower variance than averages over n independent uniforms. It panics if n < 0.
+func Stratified(src Source, n int) []float64 {
+	if n < 0 {
+		panic("milkrandom: argument to Stratified is < 0")
+	}
+	points := make([]float64, n)
+	for i := range points {
+		points[i] = stratumPoint(src, i, n)
+	}
+	return points
+}
+
+// stratumPoint returns a uniform point in the stratum [i/n, (i+1)/n), guarding against
+// rounding up to the next stratum.
+func stratumPoint(src Source, i, n int) float64 {
+	x := (float64(i) + float64From(src)) / float64(n)
+	if hi := float64(i+1) / float64(n); x >= hi {
+		x = math.Nextafter(hi, 0)
+	}
+	return x
+}
+
+// LatinHypercube returns a Latin hypercube design of samples points in [0, 1)^dims. In every
+// dimension the points occupy each of the samples strata exactly once, in an independent random
+// order per dimension, and are jittered uniformly within their stratum. It panics if samples < 0
+// or dims < 0.
+func LatinHypercube(src Source, samples, dims int) [][]float64 {
+	if samples < 0 || dims < 0 {
+		panic("milkrandom: arguments to LatinHypercube must be non-negative")
+	}
+	points := make([][]float64, samples)
+	for i := range points {
+		points[i] = make([]float64, dims)
+	}
+	perm := make([]int, samples)
+	for d := 0; d < dims; d++ {
+		PermInto(src, perm)
+		for i, stratum := range perm {
+			points[i][d] = stratumPoint(src, stratum, samples)
+		}
+	}
+	return points
+}
@@ -0,0 +1,157 @@
+package milkrandom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAntitheticPairs(t *testing.T) {
+	src := newTestSource(1)
+	for _, p := range AntitheticPairs(src, 1000) {
+		if p[0] < 0 || p[0] >= 1 || p[0]+p[1] != 1 {
+			t.Fatalf("pair %v is not (u, 1-u) with u in [0, 1)", p)
+		}
+	}
+	if len(AntitheticPairs(src, 0)) != 0 {
+		t.Error("AntitheticPairs(0) returned pairs")
+	}
+
+	// Estimate the integral of exp over [0, 1) many times, each from 2n values, and compare
+	// the spread of antithetic estimates with that of estimates from independent uniforms.
+	const n, estimates = 50, 2000
+	var anti, indep []float64
+	for e := 0; e < estimates; e++ {
+		sumA, sumI := 0.0, 0.0
+		for _, p := range AntitheticPairs(src, n) {
+			sumA += math.Exp(p[0]) + math.Exp(p[1])
+		}
+		for i := 0; i < 2*n; i++ {
+			sumI += math.Exp(float64From(src))
+		}
+		anti = append(anti, sumA/(2*n))
+		indep = append(indep, sumI/(2*n))
+	}
+	meanA, varA := meanVariance(anti)
+	_, varI := meanVariance(indep)
+	if math.Abs(meanA-(math.E-1)) > 0.001 {
+		t.Errorf("antithetic estimate of the integral = %v, want %v", meanA, math.E-1)
+	}
+	// For exp the antithetic variance is about 3% of the independent one.
+	if varA > 0.1*varI {
+		t.Errorf("antithetic variance %v is not well below independent variance %v", varA, varI)
+	}
+}
+
+// meanVariance returns the sample mean and unbiased sample variance of xs.
+func meanVariance(xs []float64) (mean, v float64) {
+	for _, x := range xs {
+		mean += x
+	}
+	mean /= float64(len(xs))
+	for _, x := range xs {
+		v += (x - mean) * (x - mean)
+	}
+	return mean, v / float64(len(xs)-1)
+}
+
+func TestStratified(t *testing.T) {
+	src := newTestSource(1)
+	for _, n := range []int{1, 2, 7, 1000} {
+		points := Stratified(src, n)
+		if len(points) != n {
+			t.Fatalf("Stratified(%d) returned %d points", n, len(points))
+		}
+		for i, x := range points {
+			if stratum := int(x * float64(n)); stratum != i {
+				t.Fatalf("Stratified(%d)[%d] = %v lies in stratum %d", n, i, x, stratum)
+			}
+		}
+	}
+	if len(Stratified(src, 0)) != 0 {
+		t.Error("Stratified(0) returned points")
+	}
+
+	// Estimate the integral of exp over [0, 1) from n stratified and n independent points.
+	const n, estimates = 50, 2000
+	var strat, indep []float64
+	for e := 0; e < estimates; e++ {
+		sumS, sumI := 0.0, 0.0
+		for _, x := range Stratified(src, n) {
+			sumS += math.Exp(x)
+		}
+		for i := 0; i < n; i++ {
+			sumI += math.Exp(float64From(src))
+		}
+		strat = append(strat, sumS/n)
+		indep = append(indep, sumI/n)
+	}
+	meanS, varS := meanVariance(strat)
+	_, varI := meanVariance(indep)
+	if math.Abs(meanS-(math.E-1)) > 0.001 {
+		t.Errorf("stratified estimate of the integral = %v, want %v", meanS, math.E-1)
+	}
+	// Stratification shrinks the variance of a smooth integrand by a factor of order n^2.
+	if varS > 0.01*varI {
+		t.Errorf("stratified variance %v is not well below independent variance %v", varS, varI)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Stratified(-1) did not panic")
+		}
+	}()
+	Stratified(src, -1)
+}
+
+func TestLatinHypercube(t *testing.T) {
+	const samples, dims = 100, 4
+	points := LatinHypercube(newTestSource(1), samples, dims)
+	if len(points) != samples {
+		t.Fatalf("LatinHypercube returned %d points, want %d", len(points), samples)
+	}
+	for d := 0; d < dims; d++ {
+		seen := make([]bool, samples)
+		for _, p := range points {
+			if len(p) != dims {
+				t.Fatalf("point %v has %d coordinates, want %d", p, len(p), dims)
+			}
+			stratum := int(p[d] * samples)
+			if p[d] < 0 || stratum >= samples || seen[stratum] {
+				t.Fatalf("dimension %d: %v is outside [0, 1) or in an already used stratum", d, p[d])
+			}
+			seen[stratum] = true
+		}
+	}
+	// Independent permutations per dimension: the first two columns are not the same order.
+	same := true
+	for _, p := range points {
+		if int(p[0]*samples) != int(p[1]*samples) {
+			same = false
+		}
+	}
+	if same {
+		t.Error("every dimension uses the same stratum order")
+	}
+
+	again := LatinHypercube(newTestSource(1), samples, dims)
+	for i := range points {
+		for d := range points[i] {
+			if points[i][d] != again[i][d] {
+				t.Fatalf("point %d differs between sources with the same seed: %v, %v", i, points[i], again[i])
+			}
+		}
+	}
+	if len(LatinHypercube(newTestSource(1), 0, dims)) != 0 {
+		t.Error("LatinHypercube with no samples returned points")
+	}
+	if p := LatinHypercube(newTestSource(1), 3, 0); len(p) != 3 || len(p[0]) != 0 {
+		t.Errorf("LatinHypercube(3, 0) = %v, want 3 empty points", p)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("LatinHypercube with negative dims did not panic")
+		}
+	}()
+	LatinHypercube(newTestSource(1), 3, -1)
+}
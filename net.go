@@ -0,0 +1,41 @@
+package milkrandom
+
+import (
+	"encoding/binary"
+	"net"
+)
+
+// IPv4 generates a random IPv4 address.
+func IPv4(src Source) net.IP {
+	ip := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(ip, uint32(src.Uint64()>>32))
+	return ip
+}
+
+// IPv6 generates a random IPv6 address.
+func IPv6(src Source) net.IP {
+	ip := make(net.IP, net.IPv6len)
+	binary.BigEndian.PutUint64(ip[0:], src.Uint64())
+	binary.BigEndian.PutUint64(ip[8:], src.Uint64())
+	return ip
+}
+
+// IPInCIDR generates a random address inside the network described by cidr, such as
+// "192.168.0.0/16" or "2001:db8::/32". The network bits are kept and the host bits are random.
+func IPInCIDR(src Source, cidr string) (net.IP, error) {
+	_, ipnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+	var r net.IP
+	if len(ipnet.IP) == net.IPv4len {
+		r = IPv4(src)
+	} else {
+		r = IPv6(src)
+	}
+	ip := make(net.IP, len(ipnet.IP))
+	for i := range ip {
+		ip[i] = ipnet.IP[i] | (r[i] &^ ipnet.Mask[i])
+	}
+	return ip, nil
+}
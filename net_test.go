@@ -0,0 +1,42 @@
+package milkrandom
+
+import (
+	"net"
+	"testing"
+)
+
+func TestIPInCIDR(t *testing.T) {
+	for _, cidr := range []string{"192.168.0.0/16", "10.1.2.0/30", "2001:db8::/32", "0.0.0.0/0"} {
+		_, ipnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		a, b := newTestSource(3), newTestSource(3)
+		for i := 0; i < 1000; i++ {
+			ip, err := IPInCIDR(a, cidr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !ipnet.Contains(ip) {
+				t.Fatalf("IPInCIDR(%q) = %v, outside the network", cidr, ip)
+			}
+			again, _ := IPInCIDR(b, cidr)
+			if !ip.Equal(again) {
+				t.Fatalf("IPInCIDR(%q) not reproducible: %v != %v", cidr, ip, again)
+			}
+		}
+	}
+	if _, err := IPInCIDR(newTestSource(1), "192.168.0.0/33"); err == nil {
+		t.Error("IPInCIDR accepted an invalid CIDR")
+	}
+}
+
+func TestIPv4IPv6(t *testing.T) {
+	src := newTestSource(1)
+	if ip := IPv4(src); ip.To4() == nil {
+		t.Errorf("IPv4() = %v, not an IPv4 address", ip)
+	}
+	if ip := IPv6(src); len(ip) != net.IPv6len {
+		t.Errorf("IPv6() = %v, not an IPv6 address", ip)
+	}
+}
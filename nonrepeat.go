@@ -0,0 +1,48 @@
+package milkrandom
+
+import "sort"
+
+// NonRepeating draws integers in [0, n) while never returning a value that was returned
+// by any of the previous k calls, as in shuffle-play.
+type NonRepeating struct {
+	src    Source
+	n      int
+	k      int
+	recent []int // ring buffer of the last k values
+	next   int   // position in recent to overwrite once it is full
+	sorted []int // scratch space for the sorted recent values
+}
+
+// NewNonRepeating creates a new NonRepeating over [0, n) that avoids the last k values.
+// It panics if n <= 0, k < 0 or k >= n.
+func NewNonRepeating(src Source, n, k int) *NonRepeating {
+	if n <= 0 {
+		panic("milkrandom: argument n to NewNonRepeating is <= 0")
+	}
+	if k < 0 || k >= n {
+		panic("milkrandom: argument k to NewNonRepeating is not in [0, n)")
+	}
+	return &NonRepeating{src: src, n: n, k: k, recent: make([]int, 0, k), sorted: make([]int, 0, k)}
+}
+
+// Next returns a value in [0, n) chosen uniformly among the values not returned by the last k calls.
+// It uses a single bounded draw per call.
+func (r *NonRepeating) Next() int {
+	r.sorted = append(r.sorted[:0], r.recent...)
+	sort.Ints(r.sorted)
+	v := intn(r.src, r.n-len(r.sorted))
+	for _, h := range r.sorted { // skip over excluded values in increasing order
+		if v >= h {
+			v++
+		}
+	}
+	if r.k > 0 {
+		if len(r.recent) < r.k {
+			r.recent = append(r.recent, v)
+		} else {
+			r.recent[r.next] = v
+			r.next = (r.next + 1) % r.k
+		}
+	}
+	return v
+}
@@ -0,0 +1,44 @@
+package milkrandom
+
+import "testing"
+
+func TestNonRepeatingWindow(t *testing.T) {
+	for _, c := range []struct{ n, k int }{{10, 0}, {10, 3}, {10, 9}, {2, 1}} {
+		r := NewNonRepeating(newTestSource(1), c.n, c.k)
+		const draws = 50000
+		out := make([]int, draws)
+		counts := make([]int, c.n)
+		for i := range out {
+			v := r.Next()
+			if v < 0 || v >= c.n {
+				t.Fatalf("n=%d k=%d: Next() = %d, out of range", c.n, c.k, v)
+			}
+			out[i] = v
+			counts[v]++
+			for j := i - c.k; j < i; j++ {
+				if j >= 0 && out[j] == v {
+					t.Fatalf("n=%d k=%d: output %d repeats output %d, within a window of %d", c.n, c.k, i, j, c.k+1)
+				}
+			}
+		}
+		want := draws / c.n
+		for v, got := range counts {
+			if got < want*95/100 || got > want*105/100 {
+				t.Errorf("n=%d k=%d: value %d drawn %d times, want about %d", c.n, c.k, v, got, want)
+			}
+		}
+	}
+}
+
+func TestNewNonRepeatingInvalid(t *testing.T) {
+	for _, c := range []struct{ n, k int }{{0, 0}, {5, 5}, {5, -1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewNonRepeating(src, %d, %d) did not panic", c.n, c.k)
+				}
+			}()
+			NewNonRepeating(newTestSource(1), c.n, c.k)
+		}()
+	}
+}
@@ -3,32 +3,60 @@ package pcg32
 
 import (
 	"encoding/binary"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"math/bits"
+	"strconv"
 	"time"
 )
 
 // PCG32 represents the state of a PCG-32 random number generator.
 type PCG32 struct {
-	state uint64
-	inc   uint64
+	state  uint64
+	inc    uint64
+	seed   uint64 // last value passed to Seed, valid while seeded is set
+	seeded bool
 }
 
+// PCG32 implements milkrandom.Source, which this package does not import.
+var _ interface{ Uint64() uint64 } = (*PCG32)(nil)
+
 // New creates a new PCG32 instance seeded with the current time.
 func New() *PCG32 {
 	p := &PCG32{}
-	p.Seed(uint64(time.Now().UnixNano()))
+	p.seedClock()
 	return p
 }
 
+// NewSnapshot creates a new PCG32 instance seeded with seed and returns it together with its marshaled
+// initial state, so the exact starting point can later be restored with Unmarshal.
+func NewSnapshot(seed uint64) (*PCG32, []byte) {
+	p := &PCG32{}
+	p.Seed(seed)
+	state, _ := p.Marshal() // Marshal never fails
+	return p, state
+}
+
 // State returns the current state of the random number generator.
 func (p *PCG32) State() (uint64, uint64) {
 	return p.state, p.inc
 }
 
+// Checksum returns a 64-bit hash of the current state of the random number generator.
+// Identical states produce identical checksums, so it can be used to compare generators without exposing their state.
+func (p *PCG32) Checksum() uint64 {
+	var s uint64
+	for _, v := range [2]uint64{p.state, p.inc} {
+		s ^= v
+		s = splitmix64(&s)
+	}
+	return splitmix64(&s)
+}
+
 // Reset resets the state of the random number generator to the seed value.
 func (p *PCG32) Reset() {
-	p.Seed(uint64(time.Now().UnixNano()))
+	p.seedClock()
 }
 
 // Marshal returns the binary encoding of the current state of the random number generator.
@@ -46,11 +74,41 @@ func (p *PCG32) Unmarshal(data []byte) error {
 	}
 	p.state = binary.LittleEndian.Uint64(data[0:])
 	p.inc = binary.LittleEndian.Uint64(data[8:])
+	p.seeded = false
+	return nil
+}
+
+// MarshalJSON returns a canonical JSON encoding of the current state of the random number generator.
+// Keys are sorted and values are written as fixed-width zero-padded hexadecimal, so equal states always encode to identical bytes.
+func (p *PCG32) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`{"inc":"%016x","state":"%016x"}`, p.inc, p.state)), nil
+}
+
+// UnmarshalJSON sets the state of the random number generator to the state represented by the JSON input data.
+func (p *PCG32) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Inc   string `json:"inc"`
+		State string `json:"state"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	inc, err := parseHex64(v.Inc)
+	if err != nil {
+		return err
+	}
+	state, err := parseHex64(v.State)
+	if err != nil {
+		return err
+	}
+	p.state, p.inc = state, inc
+	p.seeded = false
 	return nil
 }
 
 // Seed initializes the state of the random number generator with the given seed value.
 func (p *PCG32) Seed(seed uint64) {
+	p.seed, p.seeded = seed, seed != 0
 	if seed == 0 {
 		seed = uint64(time.Now().UnixNano())
 	}
@@ -61,6 +119,23 @@ func (p *PCG32) Seed(seed uint64) {
 	p.Next()
 }
 
+// SeedN initializes the state of the random number generator from multiple seed values.
+// The values are folded together with SplitMix64 mixing, so the same sequence of values always yields the same state.
+func (p *PCG32) SeedN(seeds ...uint64) {
+	var s uint64
+	for _, v := range seeds {
+		s ^= v
+		s = splitmix64(&s)
+	}
+	initState := splitmix64(&s)
+	p.state = 0
+	p.inc = (splitmix64(&s) << 1) | 1
+	p.Next()
+	p.state += initState
+	p.Next()
+	p.seeded = false
+}
+
 // Next generates a random 32-bit unsigned integer.
 func (p *PCG32) Next() uint32 {
 	oldState := p.state
@@ -70,11 +145,35 @@ func (p *PCG32) Next() uint32 {
 	return bits.RotateLeft32(xorshifted, -int(rot))
 }
 
+// Uint64 generates a random 64-bit unsigned integer from two consecutive outputs.
+func (p *PCG32) Uint64() uint64 {
+	return uint64(p.Next())<<32 | uint64(p.Next())
+}
+
 // Float64 generates a random float64 in the range [0.0, 1.0).
 func (p *PCG32) Float64() float64 {
 	return float64(p.Next()) / (1 << 32)
 }
 
+// Float64Closed generates a random float64 in the closed range [0.0, 1.0].
+// Unlike Float64, both 0.0 and 1.0 can be returned.
+func (p *PCG32) Float64Closed() float64 {
+	return float64(p.Next()) / (1<<32 - 1)
+}
+
+// Float64Bits generates a random float64 in the range [0.0, 1.0) using exactly mantissaBits bits of randomness.
+// The result is a multiple of 2^-mantissaBits. Requests for more than 32 bits consume two outputs.
+// It panics if mantissaBits is not in [1, 53].
+func (p *PCG32) Float64Bits(mantissaBits int) float64 {
+	if mantissaBits < 1 || mantissaBits > 53 {
+		panic("pcg32: argument to Float64Bits is not in [1, 53]")
+	}
+	if mantissaBits <= 32 {
+		return float64(p.Next()>>(32-mantissaBits)) / float64(uint64(1)<<mantissaBits)
+	}
+	return float64(p.Uint64()>>(64-mantissaBits)) / float64(uint64(1)<<mantissaBits)
+}
+
 // Float32 generates a random float32 in the range [0.0, 1.0).
 func (p *PCG32) Float32() float32 {
 	return float32(p.Next()) / (1 << 32)
@@ -86,17 +185,218 @@ func (p *PCG32) Int32() int32 {
 }
 
 // Int generates a random integer in the range [0, n).
+// Bounds above 2^31 do not fit the 32-bit rejection threshold and are drawn from two consecutive outputs instead.
 func (p *PCG32) Int(n int) int {
 	if n <= 0 {
 		panic("pcg32: argument to Int is <= 0")
 	}
+	if uint64(n) > 1<<31 {
+		return p.int63(uint64(n))
+	}
 	if n&(n-1) == 0 { // n is 2^m, use mask
 		return int(p.Next() & uint32(n-1))
 	}
-	max := uint32((1 << 31) - 1 - (1<<31)%uint32(n))
+	// Reject the 2^32 mod n smallest draws so that the rest split evenly into n residues.
+	threshold := -uint32(n) % uint32(n)
 	v := p.Next()
-	for v > max {
+	for v < threshold {
 		v = p.Next()
 	}
 	return int(v % uint32(n))
 }
+
+// int63 generates a random integer in the range [0, n) for 0 < n <= 2^63 using 64-bit draws.
+func (p *PCG32) int63(n uint64) int {
+	if n&(n-1) == 0 { // n is 2^m, use mask
+		return int(p.Uint64() & (n - 1))
+	}
+	threshold := -n % n // 2^64 mod n
+	v := p.Uint64()
+	for v < threshold {
+		v = p.Uint64()
+	}
+	return int(v % n)
+}
+
+// Uint64Range generates a random 64-bit unsigned integer in the range [min, max).
+// It panics if min >= max.
+func (p *PCG32) Uint64Range(min, max uint64) uint64 {
+	if min >= max {
+		panic("pcg32: invalid argument to Uint64Range")
+	}
+	n := max - min
+	if n&(n-1) == 0 { // n is 2^m, use mask
+		return min + p.Uint64()&(n-1)
+	}
+	threshold := -n % n // 2^64 mod n
+	v := p.Uint64()
+	for v < threshold {
+		v = p.Uint64()
+	}
+	return min + v%n
+}
+
+// Split generates a random 64-bit unsigned integer and a float64 in the range [0.0, 1.0) derived from the same draw.
+// The float is built from the high 32 bits exactly as Float64 builds it from a single output.
+func (p *PCG32) Split() (uint64, float64) {
+	v := p.Uint64()
+	return v, float64(v>>32) / (1 << 32)
+}
+
+// FillIntN fills dst with random integers in the range [0, n).
+// For n <= 2^16 each 64-bit draw is split into four 16-bit chunks, used from the least significant end,
+// and chunks at or above the largest multiple of n are rejected. Larger n fall back to one Int call per element,
+// so for those bounds the output matches calling Int repeatedly. It panics if n <= 0.
+func (p *PCG32) FillIntN(dst []int, n int) {
+	if n <= 0 {
+		panic("pcg32: argument to FillIntN is <= 0")
+	}
+	if n > 1<<16 {
+		for i := range dst {
+			dst[i] = p.Int(n)
+		}
+		return
+	}
+	limit := uint64(1<<16 - (1<<16)%n)
+	for i := 0; i < len(dst); {
+		v := p.Uint64()
+		for j := 0; j < 4 && i < len(dst); j++ {
+			c := v & 0xffff
+			v >>= 16
+			if c < limit {
+				dst[i] = int(c % uint64(n))
+				i++
+			}
+		}
+	}
+}
+
+// ShuffleRange shuffles the elements with indices in [i, j) using the Fisher–Yates algorithm,
+// leaving all other elements in place. swap swaps the elements with indexes a and b.
+// It panics if i < 0 or j < i.
+func (p *PCG32) ShuffleRange(i, j int, swap func(a, b int)) {
+	if i < 0 || j < i {
+		panic("pcg32: invalid argument to ShuffleRange")
+	}
+	for k := j - 1; k > i; k-- {
+		swap(k, i+p.Int(k-i+1))
+	}
+}
+
+// Stir deterministically reseeds the random number generator from its own state by passing every
+// state word through SeedN. The resulting state is a fixed function of the current one, so replays
+// stay reproducible, but the generator continues on a different stream than it would have without Stir.
+func (p *PCG32) Stir() {
+	p.SeedN(p.state, p.inc)
+}
+
+// FullInt64 generates a random 64-bit signed integer over the full range [math.MinInt64, math.MaxInt64].
+// Unlike Int64, which is always non-negative, it reinterprets all 64 output bits in two's complement,
+// so outputs with the high bit set are negative and int64(uint64(v)) round-trips exactly. It combines two consecutive outputs.
+func (p *PCG32) FullInt64() int64 {
+	return int64(p.Uint64())
+}
+
+// Derive returns a new, independent random number generator seeded from the seed of this one combined with name.
+// The same seed and name always yield the same sub-generator, however many values this generator has produced
+// since Seed, while different names yield unrelated streams. If CurrentSeed reports no seed, the Checksum of the
+// current state is used in its place. The state of this generator is not advanced.
+func (p *PCG32) Derive(name string) *PCG32 {
+	base, ok := p.CurrentSeed()
+	if !ok {
+		base = p.Checksum()
+	}
+	d := &PCG32{}
+	d.SeedN(append([]uint64{base}, stringWords(name)...)...)
+	return d
+}
+
+// PermInto fills dst with a random permutation of the integers [0, len(dst)), overwriting its contents.
+// The permutation is built with the inside-out Fisher–Yates algorithm, one Int call per element after the first,
+// so the buffer can be reused across calls without allocating.
+func (p *PCG32) PermInto(dst []int) {
+	if len(dst) == 0 {
+		return
+	}
+	dst[0] = 0
+	for i := 1; i < len(dst); i++ {
+		j := p.Int(i + 1)
+		dst[i] = dst[j]
+		dst[j] = i
+	}
+}
+
+// CurrentSeed returns the seed most recently passed to Seed, so a result can be reproduced by seeding with it again.
+// ok is false if the generator was instead seeded from the clock or with SeedN, or had its state loaded with Unmarshal or UnmarshalJSON.
+func (p *PCG32) CurrentSeed() (seed uint64, ok bool) {
+	if !p.seeded {
+		return 0, false
+	}
+	return p.seed, true
+}
+
+// IntExcept generates a random integer in the range [0, n) that is never exclude, with all other values equally likely.
+// It draws from [0, n-1) and shifts values at or above exclude up by one. It panics if n <= 1 or exclude is not in [0, n).
+func (p *PCG32) IntExcept(n, exclude int) int {
+	if n <= 1 || exclude < 0 || exclude >= n {
+		panic("pcg32: invalid argument to IntExcept")
+	}
+	v := p.Int(n - 1)
+	if v >= exclude {
+		v++
+	}
+	return v
+}
+
+// Shuffle randomizes the order of n elements with the backward Fisher–Yates algorithm. swap swaps the elements with indexes a and b.
+// The draw sequence is fixed: for i from n-1 down to 1 it calls swap(i, j) with j = Int(i + 1), so any implementation making the
+// same Int calls on the same state reproduces the result. The order of swaps matches math/rand.Shuffle, though j is drawn by this
+// generator's Int rather than by math/rand. It is equivalent to ShuffleRange(0, n, swap). It panics if n < 0.
+func (p *PCG32) Shuffle(n int, swap func(a, b int)) {
+	if n < 0 {
+		panic("pcg32: argument to Shuffle is < 0")
+	}
+	for i := n - 1; i > 0; i-- {
+		swap(i, p.Int(i+1))
+	}
+}
+
+// splitmix64 advances s and returns the next SplitMix64 output. It is used to expand seeds into the full state.
+func splitmix64(s *uint64) uint64 {
+	*s += 0x9e3779b97f4a7c15
+	z := *s
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// parseHex64 parses a 16-digit hexadecimal state word as written by MarshalJSON.
+func parseHex64(s string) (uint64, error) {
+	if len(s) != 16 {
+		return 0, errors.New("pcg32: invalid JSON state")
+	}
+	v, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return 0, errors.New("pcg32: invalid JSON state")
+	}
+	return v, nil
+}
+
+// stringWords splits s into little-endian 64-bit words, zero-padding the last one, followed by its length.
+func stringWords(s string) []uint64 {
+	words := make([]uint64, 0, len(s)/8+2)
+	for i := 0; i < len(s); i += 8 {
+		var w uint64
+		for j := i; j < len(s) && j < i+8; j++ {
+			w |= uint64(s[j]) << (8 * (j - i))
+		}
+		words = append(words, w)
+	}
+	return append(words, uint64(len(s)))
+}
+
+// seedClock seeds the random number generator from the current time. The clock value is not reported by CurrentSeed.
+func (p *PCG32) seedClock() {
+	p.Seed(uint64(time.Now().UnixNano()))
+	p.seeded = false
+}
@@ -0,0 +1,717 @@
+// Package pcg64dxsm implements the PCG-64 DXSM random number generator.
+//
+// PCG64DXSM uses a 128-bit linear congruential step with a 64-bit "cheap" multiplier and the
+// DXSM (double xorshift multiply) output function. numpy provides it as PCG64DXSM.
+package pcg64dxsm
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// cheapMultiplier is the 64-bit multiplier used by both the LCG step and the DXSM output function.
+const cheapMultiplier = 0xda942042e4dd58b5
+
+// defaultMultiplier is PCG_DEFAULT_MULTIPLIER_128 from the PCG reference, used only by SeedState to
+// advance the state while seeding, as pcg_setseq_128_srandom_r does.
+var defaultMultiplier = uint128{low: 0x4385df649fccf645, high: 0x2360ed051fc65da4}
+
+// PCG64DXSM represents the state of a PCG-64 DXSM random number generator.
+type PCG64DXSM struct {
+	state  uint128
+	inc    uint128
+	seed   uint64 // last value passed to Seed, valid while seeded is set
+	seeded bool
+}
+
+// SafePCG64DXSM represents the state of a PCG-64 DXSM random number generator with a mutex to make it safe for concurrent use.
+type SafePCG64DXSM struct {
+	PCG64DXSM
+	mu sync.Mutex
+}
+
+// Both generator types implement milkrandom.Source, which this package does not import.
+var (
+	_ interface{ Uint64() uint64 } = (*PCG64DXSM)(nil)
+	_ interface{ Uint64() uint64 } = (*SafePCG64DXSM)(nil)
+)
+
+// uint128 is a simple representation of a 128-bit unsigned integer
+type uint128 struct {
+	low  uint64
+	high uint64
+}
+
+// New creates a new PCG64DXSM instance seeded with the current time.
+func New() *PCG64DXSM {
+	p := &PCG64DXSM{}
+	p.seedClock()
+	return p
+}
+
+// NewSafe creates a new safe PCG64DXSM instance seeded with the current time.
+func NewSafe() *SafePCG64DXSM {
+	p := &SafePCG64DXSM{}
+	p.seedClock()
+	return p
+}
+
+// NewSafeFrom creates a new safe PCG64DXSM instance that continues from the current state of src.
+// src itself is not modified and remains unsafe for concurrent use.
+func NewSafeFrom(src *PCG64DXSM) *SafePCG64DXSM {
+	return &SafePCG64DXSM{PCG64DXSM: *src}
+}
+
+// NewSnapshot creates a new PCG64DXSM instance seeded with seed and returns it together with its marshaled
+// initial state, so the exact starting point can later be restored with Unmarshal.
+func NewSnapshot(seed uint64) (*PCG64DXSM, []byte) {
+	p := &PCG64DXSM{}
+	p.Seed(seed)
+	state, _ := p.Marshal() // Marshal never fails
+	return p, state
+}
+
+// State returns the current state of the random number generator.
+func (p *PCG64DXSM) State() (uint64, uint64, uint64, uint64) {
+	return p.state.low, p.state.high, p.inc.low, p.inc.high
+}
+
+// State returns the current state of the random number generator, which is safe for concurrent use.
+func (p *SafePCG64DXSM) State() (uint64, uint64, uint64, uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.PCG64DXSM.State()
+}
+
+// Checksum returns a 64-bit hash of the current state of the random number generator.
+// Identical states produce identical checksums, so it can be used to compare generators without exposing their state.
+func (p *PCG64DXSM) Checksum() uint64 {
+	var s uint64
+	for _, v := range [4]uint64{p.state.low, p.state.high, p.inc.low, p.inc.high} {
+		s ^= v
+		s = splitmix64(&s)
+	}
+	return splitmix64(&s)
+}
+
+// Checksum returns a 64-bit hash of the current state of the random number generator, which is safe for concurrent use.
+func (p *SafePCG64DXSM) Checksum() uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.PCG64DXSM.Checksum()
+}
+
+// Reset resets the state of the random number generator to the seed value.
+func (p *PCG64DXSM) Reset() {
+	p.seedClock()
+}
+
+// Reset resets the state of the random number generator to the seed value, which is safe for concurrent use.
+func (p *SafePCG64DXSM) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.PCG64DXSM.Reset()
+}
+
+// Marshal returns the binary encoding of the current state of the random number generator.
+func (p *PCG64DXSM) Marshal() ([]byte, error) {
+	buf := make([]byte, 32)
+	binary.LittleEndian.PutUint64(buf[0:], p.state.low)
+	binary.LittleEndian.PutUint64(buf[8:], p.state.high)
+	binary.LittleEndian.PutUint64(buf[16:], p.inc.low)
+	binary.LittleEndian.PutUint64(buf[24:], p.inc.high)
+	return buf, nil
+}
+
+// Marshal returns the binary encoding of the current state of the random number generator, which is safe for concurrent use.
+func (p *SafePCG64DXSM) Marshal() ([]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.PCG64DXSM.Marshal()
+}
+
+// Unmarshal sets the state of the random number generator to the state represented by the input data.
+func (p *PCG64DXSM) Unmarshal(data []byte) error {
+	if len(data) != 32 {
+		return errors.New("pcg64dxsm: invalid state length")
+	}
+	p.state.low = binary.LittleEndian.Uint64(data[0:])
+	p.state.high = binary.LittleEndian.Uint64(data[8:])
+	p.inc.low = binary.LittleEndian.Uint64(data[16:])
+	p.inc.high = binary.LittleEndian.Uint64(data[24:])
+	p.seeded = false
+	return nil
+}
+
+// Unmarshal sets the state of the random number generator to the state represented by the input data, which is safe for concurrent use.
+func (p *SafePCG64DXSM) Unmarshal(data []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.PCG64DXSM.Unmarshal(data)
+}
+
+// MarshalJSON returns a canonical JSON encoding of the current state of the random number generator.
+// Keys are sorted and each 128-bit value is written as 32 zero-padded hexadecimal digits, so equal states always encode to identical bytes.
+func (p *PCG64DXSM) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`{"inc":"%016x%016x","state":"%016x%016x"}`,
+		p.inc.high, p.inc.low, p.state.high, p.state.low)), nil
+}
+
+// MarshalJSON returns a canonical JSON encoding of the current state of the random number generator, which is safe for concurrent use.
+func (p *SafePCG64DXSM) MarshalJSON() ([]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.PCG64DXSM.MarshalJSON()
+}
+
+// UnmarshalJSON sets the state of the random number generator to the state represented by the JSON input data.
+func (p *PCG64DXSM) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Inc   string `json:"inc"`
+		State string `json:"state"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	inc, err := parseHex128(v.Inc)
+	if err != nil {
+		return err
+	}
+	state, err := parseHex128(v.State)
+	if err != nil {
+		return err
+	}
+	p.state, p.inc = state, inc
+	p.seeded = false
+	return nil
+}
+
+// UnmarshalJSON sets the state of the random number generator to the state represented by the JSON input data, which is safe for concurrent use.
+func (p *SafePCG64DXSM) UnmarshalJSON(data []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.PCG64DXSM.UnmarshalJSON(data)
+}
+
+// Seed initializes the state of the random number generator with the given seed value.
+// The seed is expanded into the 128-bit initial state and stream with SplitMix64.
+func (p *PCG64DXSM) Seed(seed uint64) {
+	explicit := seed != 0
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	s := seed
+	p.SeedState(splitmix64(&s), splitmix64(&s), splitmix64(&s), splitmix64(&s))
+	p.seed, p.seeded = seed, explicit
+}
+
+// Seed initializes the state of the random number generator with the given seed value, which is safe for concurrent use.
+func (p *SafePCG64DXSM) Seed(seed uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.PCG64DXSM.Seed(seed)
+}
+
+// SeedN initializes the state of the random number generator from multiple seed values.
+// The values are folded together with SplitMix64 mixing, so the same sequence of values always yields the same state.
+func (p *PCG64DXSM) SeedN(seeds ...uint64) {
+	var s uint64
+	for _, v := range seeds {
+		s ^= v
+		s = splitmix64(&s)
+	}
+	p.SeedState(splitmix64(&s), splitmix64(&s), splitmix64(&s), splitmix64(&s))
+}
+
+// SeedN initializes the state of the random number generator from multiple seed values, which is safe for concurrent use.
+func (p *SafePCG64DXSM) SeedN(seeds ...uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.PCG64DXSM.SeedN(seeds...)
+}
+
+// SeedState initializes the random number generator from a 128-bit initial state and a 128-bit stream
+// selector, each given as its high and low 64-bit halves. It follows the reference pcg_setseq_128_srandom_r
+// procedure that numpy's pcg64_set_seed calls, which advances the state with the 128-bit default multiplier
+// rather than the cheap one used afterwards.
+func (p *PCG64DXSM) SeedState(stateHigh, stateLow, seqHigh, seqLow uint64) {
+	p.state = uint128{low: 0, high: 0}
+	p.inc = uint128{low: seqLow<<1 | 1, high: seqHigh<<1 | seqLow>>63}
+	p.seedStep()
+	p.state = add128(p.state, uint128{low: stateLow, high: stateHigh})
+	p.seedStep()
+	p.seeded = false
+}
+
+// SeedState initializes the random number generator from a 128-bit initial state and stream selector, which is safe for concurrent use.
+func (p *SafePCG64DXSM) SeedState(stateHigh, stateLow, seqHigh, seqLow uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.PCG64DXSM.SeedState(stateHigh, stateLow, seqHigh, seqLow)
+}
+
+// seedStep advances the 128-bit LCG state using the 128-bit default multiplier.
+func (p *PCG64DXSM) seedStep() {
+	p.state = add128(mul128(p.state, defaultMultiplier), p.inc)
+}
+
+// step advances the 128-bit LCG state using the cheap 64-bit multiplier.
+func (p *PCG64DXSM) step() {
+	p.state = add128(mul128(p.state, uint128{low: cheapMultiplier, high: 0}), p.inc)
+}
+
+// Next generates a random 64-bit unsigned integer.
+// The DXSM output function is applied to the state before it is advanced.
+func (p *PCG64DXSM) Next() uint64 {
+	hi := p.state.high
+	lo := p.state.low | 1
+	hi ^= hi >> 32
+	hi *= cheapMultiplier
+	hi ^= hi >> 48
+	hi *= lo
+	p.step()
+	return hi
+}
+
+// Next generates a random 64-bit unsigned integer, which is safe for concurrent use.
+func (p *SafePCG64DXSM) Next() uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.PCG64DXSM.Next()
+}
+
+// Uint64 generates a random 64-bit unsigned integer. It is equivalent to Next.
+func (p *PCG64DXSM) Uint64() uint64 {
+	return p.Next()
+}
+
+// Uint64 generates a random 64-bit unsigned integer, which is safe for concurrent use.
+func (p *SafePCG64DXSM) Uint64() uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.PCG64DXSM.Next()
+}
+
+// Int64 generates a random 64-bit signed integer.
+func (p *PCG64DXSM) Int64() int64 {
+	return int64(p.Next() >> 1)
+}
+
+// Int64 generates a random 64-bit signed integer, which is safe for concurrent use.
+func (p *SafePCG64DXSM) Int64() int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.PCG64DXSM.Int64()
+}
+
+// Uint32 generates a random 32-bit unsigned integer.
+func (p *PCG64DXSM) Uint32() uint32 {
+	return uint32(p.Next() >> 32)
+}
+
+// Uint32 generates a random 32-bit unsigned integer, which is safe for concurrent use.
+func (p *SafePCG64DXSM) Uint32() uint32 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.PCG64DXSM.Uint32()
+}
+
+// Int32 generates a random 32-bit signed integer.
+func (p *PCG64DXSM) Int32() int32 {
+	return int32(p.Uint32() >> 1)
+}
+
+// Int32 generates a random 32-bit signed integer, which is safe for concurrent use.
+func (p *SafePCG64DXSM) Int32() int32 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.PCG64DXSM.Int32()
+}
+
+// Int generates a random integer in the range [0, n).
+func (p *PCG64DXSM) Int(n int) int {
+	if n <= 0 {
+		panic("pcg64dxsm: argument to Int is <= 0")
+	}
+	if n&(n-1) == 0 { // n is 2^m, use mask
+		return int(p.Next() & uint64(n-1))
+	}
+	// Reject the 2^64 mod n smallest draws so that the rest split evenly into n residues.
+	threshold := -uint64(n) % uint64(n)
+	v := p.Next()
+	for v < threshold {
+		v = p.Next()
+	}
+	return int(v % uint64(n))
+}
+
+// Int generates a random integer in the range [0, n), which is safe for concurrent use.
+func (p *SafePCG64DXSM) Int(n int) int {
+	if n <= 0 {
+		panic("pcg64dxsm: argument to Int is <= 0")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.PCG64DXSM.Int(n)
+}
+
+// Uint64Range generates a random 64-bit unsigned integer in the range [min, max).
+// It panics if min >= max.
+func (p *PCG64DXSM) Uint64Range(min, max uint64) uint64 {
+	if min >= max {
+		panic("pcg64dxsm: invalid argument to Uint64Range")
+	}
+	n := max - min
+	if n&(n-1) == 0 { // n is 2^m, use mask
+		return min + p.Next()&(n-1)
+	}
+	threshold := -n % n // 2^64 mod n
+	v := p.Next()
+	for v < threshold {
+		v = p.Next()
+	}
+	return min + v%n
+}
+
+// Uint64Range generates a random 64-bit unsigned integer in the range [min, max), which is safe for concurrent use.
+func (p *SafePCG64DXSM) Uint64Range(min, max uint64) uint64 {
+	if min >= max {
+		panic("pcg64dxsm: invalid argument to Uint64Range")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.PCG64DXSM.Uint64Range(min, max)
+}
+
+// Float64 generates a random float64 in the range [0.0, 1.0).
+func (p *PCG64DXSM) Float64() float64 {
+	return float64(p.Next()>>(64-53)) / (1 << 53)
+}
+
+// Float64 generates a random float64 in the range [0.0, 1.0), which is safe for concurrent use.
+func (p *SafePCG64DXSM) Float64() float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.PCG64DXSM.Float64()
+}
+
+// Float64Closed generates a random float64 in the closed range [0.0, 1.0].
+// Unlike Float64, both 0.0 and 1.0 can be returned.
+func (p *PCG64DXSM) Float64Closed() float64 {
+	return float64(p.Next()>>(64-53)) / (1<<53 - 1)
+}
+
+// Float64Closed generates a random float64 in the closed range [0.0, 1.0], which is safe for concurrent use.
+func (p *SafePCG64DXSM) Float64Closed() float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.PCG64DXSM.Float64Closed()
+}
+
+// Float64Bits generates a random float64 in the range [0.0, 1.0) using exactly mantissaBits bits of randomness.
+// The result is a multiple of 2^-mantissaBits. It panics if mantissaBits is not in [1, 53].
+func (p *PCG64DXSM) Float64Bits(mantissaBits int) float64 {
+	if mantissaBits < 1 || mantissaBits > 53 {
+		panic("pcg64dxsm: argument to Float64Bits is not in [1, 53]")
+	}
+	return float64(p.Next()>>(64-mantissaBits)) / float64(uint64(1)<<mantissaBits)
+}
+
+// Float64Bits generates a random float64 in the range [0.0, 1.0) using exactly mantissaBits bits of randomness, which is safe for concurrent use.
+func (p *SafePCG64DXSM) Float64Bits(mantissaBits int) float64 {
+	if mantissaBits < 1 || mantissaBits > 53 {
+		panic("pcg64dxsm: argument to Float64Bits is not in [1, 53]")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.PCG64DXSM.Float64Bits(mantissaBits)
+}
+
+// Float32 generates a random float32 in the range [0.0, 1.0).
+func (p *PCG64DXSM) Float32() float32 {
+	return float32(p.Uint32()>>(32-24)) / (1 << 24)
+}
+
+// Float32 generates a random float32 in the range [0.0, 1.0), which is safe for concurrent use.
+func (p *SafePCG64DXSM) Float32() float32 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.PCG64DXSM.Float32()
+}
+
+// Split generates a random 64-bit unsigned integer and a float64 in the range [0.0, 1.0) derived from the same draw.
+// The float is built from the high 53 bits exactly as Float64 builds it.
+func (p *PCG64DXSM) Split() (uint64, float64) {
+	v := p.Next()
+	return v, float64(v>>(64-53)) / (1 << 53)
+}
+
+// Split generates a random 64-bit unsigned integer and a float64 derived from the same draw, which is safe for concurrent use.
+func (p *SafePCG64DXSM) Split() (uint64, float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.PCG64DXSM.Split()
+}
+
+// FillIntN fills dst with random integers in the range [0, n).
+// For n <= 2^16 each 64-bit draw is split into four 16-bit chunks, used from the least significant end,
+// and chunks at or above the largest multiple of n are rejected. Larger n fall back to one Int call per element,
+// so for those bounds the output matches calling Int repeatedly. It panics if n <= 0.
+func (p *PCG64DXSM) FillIntN(dst []int, n int) {
+	if n <= 0 {
+		panic("pcg64dxsm: argument to FillIntN is <= 0")
+	}
+	if n > 1<<16 {
+		for i := range dst {
+			dst[i] = p.Int(n)
+		}
+		return
+	}
+	limit := uint64(1<<16 - (1<<16)%n)
+	for i := 0; i < len(dst); {
+		v := p.Next()
+		for j := 0; j < 4 && i < len(dst); j++ {
+			c := v & 0xffff
+			v >>= 16
+			if c < limit {
+				dst[i] = int(c % uint64(n))
+				i++
+			}
+		}
+	}
+}
+
+// FillIntN fills dst with random integers in the range [0, n), holding the lock once for the whole slice, which is safe for concurrent use.
+func (p *SafePCG64DXSM) FillIntN(dst []int, n int) {
+	if n <= 0 {
+		panic("pcg64dxsm: argument to FillIntN is <= 0")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.PCG64DXSM.FillIntN(dst, n)
+}
+
+// ShuffleRange shuffles the elements with indices in [i, j) using the Fisher–Yates algorithm,
+// leaving all other elements in place. swap swaps the elements with indexes a and b.
+// It panics if i < 0 or j < i.
+func (p *PCG64DXSM) ShuffleRange(i, j int, swap func(a, b int)) {
+	if i < 0 || j < i {
+		panic("pcg64dxsm: invalid argument to ShuffleRange")
+	}
+	for k := j - 1; k > i; k-- {
+		swap(k, i+p.Int(k-i+1))
+	}
+}
+
+// ShuffleRange shuffles the elements with indices in [i, j), which is safe for concurrent use.
+// The lock is held for the whole shuffle, so swap must not use the generator.
+func (p *SafePCG64DXSM) ShuffleRange(i, j int, swap func(a, b int)) {
+	if i < 0 || j < i {
+		panic("pcg64dxsm: invalid argument to ShuffleRange")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.PCG64DXSM.ShuffleRange(i, j, swap)
+}
+
+// Stir deterministically reseeds the random number generator from its own state by passing every
+// state word through SeedN. The resulting state is a fixed function of the current one, so replays
+// stay reproducible, but the generator continues on a different stream than it would have without Stir.
+func (p *PCG64DXSM) Stir() {
+	p.SeedN(p.state.low, p.state.high, p.inc.low, p.inc.high)
+}
+
+// Stir deterministically reseeds the random number generator from its own state, which is safe for concurrent use.
+func (p *SafePCG64DXSM) Stir() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.PCG64DXSM.Stir()
+}
+
+// FullInt64 generates a random 64-bit signed integer over the full range [math.MinInt64, math.MaxInt64].
+// Unlike Int64, which is always non-negative, it reinterprets all 64 output bits in two's complement,
+// so outputs with the high bit set are negative and int64(uint64(v)) round-trips exactly.
+func (p *PCG64DXSM) FullInt64() int64 {
+	return int64(p.Next())
+}
+
+// FullInt64 generates a random 64-bit signed integer over the full range, which is safe for concurrent use.
+func (p *SafePCG64DXSM) FullInt64() int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.PCG64DXSM.FullInt64()
+}
+
+// Derive returns a new, independent random number generator seeded from the seed of this one combined with name.
+// The same seed and name always yield the same sub-generator, however many values this generator has produced
+// since Seed, while different names yield unrelated streams. If CurrentSeed reports no seed, the Checksum of the
+// current state is used in its place. The state of this generator is not advanced.
+func (p *PCG64DXSM) Derive(name string) *PCG64DXSM {
+	base, ok := p.CurrentSeed()
+	if !ok {
+		base = p.Checksum()
+	}
+	d := &PCG64DXSM{}
+	d.SeedN(append([]uint64{base}, stringWords(name)...)...)
+	return d
+}
+
+// Derive returns a new, independent safe random number generator seeded from the seed of this one
+// combined with name, which is safe for concurrent use.
+func (p *SafePCG64DXSM) Derive(name string) *SafePCG64DXSM {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return &SafePCG64DXSM{PCG64DXSM: *p.PCG64DXSM.Derive(name)}
+}
+
+// PermInto fills dst with a random permutation of the integers [0, len(dst)), overwriting its contents.
+// The permutation is built with the inside-out Fisher–Yates algorithm, one Int call per element after the first,
+// so the buffer can be reused across calls without allocating.
+func (p *PCG64DXSM) PermInto(dst []int) {
+	if len(dst) == 0 {
+		return
+	}
+	dst[0] = 0
+	for i := 1; i < len(dst); i++ {
+		j := p.Int(i + 1)
+		dst[i] = dst[j]
+		dst[j] = i
+	}
+}
+
+// PermInto fills dst with a random permutation of the integers [0, len(dst)), which is safe for concurrent use.
+func (p *SafePCG64DXSM) PermInto(dst []int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.PCG64DXSM.PermInto(dst)
+}
+
+// CurrentSeed returns the seed most recently passed to Seed, so a result can be reproduced by seeding with it again.
+// ok is false if the generator was instead seeded from the clock or with SeedN, or had its state loaded with Unmarshal or UnmarshalJSON.
+func (p *PCG64DXSM) CurrentSeed() (seed uint64, ok bool) {
+	if !p.seeded {
+		return 0, false
+	}
+	return p.seed, true
+}
+
+// CurrentSeed returns the seed most recently passed to Seed, which is safe for concurrent use.
+func (p *SafePCG64DXSM) CurrentSeed() (seed uint64, ok bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.PCG64DXSM.CurrentSeed()
+}
+
+// IntExcept generates a random integer in the range [0, n) that is never exclude, with all other values equally likely.
+// It draws from [0, n-1) and shifts values at or above exclude up by one. It panics if n <= 1 or exclude is not in [0, n).
+func (p *PCG64DXSM) IntExcept(n, exclude int) int {
+	if n <= 1 || exclude < 0 || exclude >= n {
+		panic("pcg64dxsm: invalid argument to IntExcept")
+	}
+	v := p.Int(n - 1)
+	if v >= exclude {
+		v++
+	}
+	return v
+}
+
+// IntExcept generates a random integer in the range [0, n) that is never exclude, which is safe for concurrent use.
+func (p *SafePCG64DXSM) IntExcept(n, exclude int) int {
+	if n <= 1 || exclude < 0 || exclude >= n {
+		panic("pcg64dxsm: invalid argument to IntExcept")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.PCG64DXSM.IntExcept(n, exclude)
+}
+
+// Shuffle randomizes the order of n elements with the backward Fisher–Yates algorithm. swap swaps the elements with indexes a and b.
+// The draw sequence is fixed: for i from n-1 down to 1 it calls swap(i, j) with j = Int(i + 1), so any implementation making the
+// same Int calls on the same state reproduces the result. The order of swaps matches math/rand.Shuffle, though j is drawn by this
+// generator's Int rather than by math/rand. It is equivalent to ShuffleRange(0, n, swap). It panics if n < 0.
+func (p *PCG64DXSM) Shuffle(n int, swap func(a, b int)) {
+	if n < 0 {
+		panic("pcg64dxsm: argument to Shuffle is < 0")
+	}
+	for i := n - 1; i > 0; i-- {
+		swap(i, p.Int(i+1))
+	}
+}
+
+// Shuffle randomizes the order of n elements with the backward Fisher–Yates algorithm, which is safe for concurrent use.
+// The lock is held for the whole shuffle, so swap must not use the generator.
+func (p *SafePCG64DXSM) Shuffle(n int, swap func(a, b int)) {
+	if n < 0 {
+		panic("pcg64dxsm: argument to Shuffle is < 0")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.PCG64DXSM.Shuffle(n, swap)
+}
+
+// Helper functions for 128-bit arithmetic
+
+func add128(a, b uint128) uint128 {
+	low := a.low + b.low
+	high := a.high + b.high
+	if low < a.low {
+		high++
+	}
+	return uint128{low: low, high: high}
+}
+
+func mul128(a, b uint128) uint128 {
+	high, low := bits.Mul64(a.low, b.low)
+	high += a.low*b.high + a.high*b.low
+	return uint128{low: low, high: high}
+}
+
+// splitmix64 advances s and returns the next SplitMix64 output. It is used to expand seeds into the full state.
+func splitmix64(s *uint64) uint64 {
+	*s += 0x9e3779b97f4a7c15
+	z := *s
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// parseHex128 parses a 32-digit hexadecimal 128-bit value as written by MarshalJSON.
+func parseHex128(s string) (uint128, error) {
+	if len(s) != 32 {
+		return uint128{}, errors.New("pcg64dxsm: invalid JSON state")
+	}
+	high, err := strconv.ParseUint(s[:16], 16, 64)
+	if err != nil {
+		return uint128{}, errors.New("pcg64dxsm: invalid JSON state")
+	}
+	low, err := strconv.ParseUint(s[16:], 16, 64)
+	if err != nil {
+		return uint128{}, errors.New("pcg64dxsm: invalid JSON state")
+	}
+	return uint128{low: low, high: high}, nil
+}
+
+// stringWords splits s into little-endian 64-bit words, zero-padding the last one, followed by its length.
+func stringWords(s string) []uint64 {
+	words := make([]uint64, 0, len(s)/8+2)
+	for i := 0; i < len(s); i += 8 {
+		var w uint64
+		for j := i; j < len(s) && j < i+8; j++ {
+			w |= uint64(s[j]) << (8 * (j - i))
+		}
+		words = append(words, w)
+	}
+	return append(words, uint64(len(s)))
+}
+
+// seedClock seeds the random number generator from the current time. The clock value is not reported by CurrentSeed.
+func (p *PCG64DXSM) seedClock() {
+	p.Seed(uint64(time.Now().UnixNano()))
+	p.seeded = false
+}
@@ -0,0 +1,125 @@
+package milkrandom
+
+import (
+	"math"
+	"sort"
+)
+
+// Perm returns a random permutation of the integers [0, n). It panics if n < 0.
+func Perm(src Source, n int) []int {
+	if n < 0 {
+		panic("milkrandom: argument to Perm is < 0")
+	}
+	m := make([]int, n)
+	PermInto(src, m)
+	return m
+}
+
+// PermInto fills dst with a random permutation of the integers [0, len(dst)), overwriting its
+// contents. It consumes the same draws as Perm and yields the same permutation for the same
+// state, but reuses dst instead of allocating.
+func PermInto(src Source, dst []int) {
+	if len(dst) == 0 {
+		return
+	}
+	dst[0] = 0
+	for i := 1; i < len(dst); i++ {
+		j := intn(src, i+1)
+		dst[i] = dst[j]
+		dst[j] = i
+	}
+}
+
+// PermutationMatrix returns a random n×n permutation matrix: every row and every column contains
+// exactly one 1 and zeros elsewhere. Row i has its 1 in column Perm(src, n)[i]. It panics if n < 0.
+func PermutationMatrix(src Source, n int) [][]float64 {
+	perm := Perm(src, n)
+	m := make([][]float64, n)
+	for i, j := range perm {
+		m[i] = make([]float64, n)
+		m[i][j] = 1
+	}
+	return m
+}
+
+// Combination returns a uniformly random k-element subset of the integers [0, n) in increasing order.
+// It uses selection sampling, visiting each integer once and keeping it with probability equal to the
+// number of elements still needed over the number still available, so it takes O(n) time.
+// It panics if n < 0 or k is not in [0, n].
+func Combination(src Source, n, k int) []int {
+	if n < 0 || k < 0 || k > n {
+		panic("milkrandom: invalid arguments to Combination")
+	}
+	c := make([]int, 0, k)
+	for i := 0; len(c) < k; i++ {
+		if intn(src, n-i) < k-len(c) {
+			c = append(c, i)
+		}
+	}
+	return c
+}
+
+// RandomPartition returns parts non-negative integers that sum to total, chosen uniformly among
+// all such ordered compositions. Following stars and bars, it picks parts-1 distinct divider
+// positions among total+parts-1 with Floyd's algorithm and returns the gaps between them, so it
+// takes O(parts log parts) time however large total is. It panics if parts <= 0, if total < 0,
+// or if total+parts-1 overflows an int64.
+func RandomPartition(src Source, total int64, parts int) []int64 {
+	if parts <= 0 {
+		panic("milkrandom: argument parts to RandomPartition is <= 0")
+	}
+	if total < 0 {
+		panic("milkrandom: argument total to RandomPartition is < 0")
+	}
+	k := int64(parts - 1)
+	if total > math.MaxInt64-k {
+		panic("milkrandom: arguments to RandomPartition overflow int64")
+	}
+	n := total + k
+	chosen := make(map[int64]struct{}, k)
+	dividers := make([]int64, 0, k)
+	for j := n - k; j < n; j++ {
+		t := int64(uint64n(src, uint64(j+1)))
+		if _, ok := chosen[t]; ok {
+			t = j
+		}
+		chosen[t] = struct{}{}
+		dividers = append(dividers, t)
+	}
+	sort.Slice(dividers, func(a, b int) bool { return dividers[a] < dividers[b] })
+	result := make([]int64, parts)
+	prev := int64(-1)
+	for i, d := range dividers {
+		result[i] = d - prev - 1
+		prev = d
+	}
+	result[parts-1] = n - prev - 1
+	return result
+}
+
+// RandomRotation returns a uniformly random rotation offset in [0, n). It panics if n <= 0.
+func RandomRotation(src Source, n int) int {
+	if n <= 0 {
+		panic("milkrandom: argument to RandomRotation is <= 0")
+	}
+	return intn(src, n)
+}
+
+// RotateSlice rotates s left in place by RandomRotation(src, len(s)), so the element at that
+// offset moves to the front and the relative cyclic order is kept. An empty slice is left as is.
+func RotateSlice[T any](src Source, s []T) {
+	if len(s) == 0 {
+		return
+	}
+	k := RandomRotation(src, len(s))
+	reverse(s[:k])
+	reverse(s[k:])
+	reverse(s)
+}
+
+// reverse reverses s in place.
+func reverse[T any](s []T) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
@@ -0,0 +1,194 @@
+package milkrandom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPermutationMatrix(t *testing.T) {
+	src := newTestSource(1)
+	for _, n := range []int{0, 1, 2, 7, 32} {
+		m := PermutationMatrix(src, n)
+		if len(m) != n {
+			t.Fatalf("PermutationMatrix(%d) has %d rows", n, len(m))
+		}
+		cols := make([]float64, n)
+		for i, row := range m {
+			if len(row) != n {
+				t.Fatalf("PermutationMatrix(%d): row %d has %d columns", n, i, len(row))
+			}
+			sum := 0.0
+			for j, v := range row {
+				if v != 0 && v != 1 {
+					t.Fatalf("PermutationMatrix(%d)[%d][%d] = %v, want 0 or 1", n, i, j, v)
+				}
+				sum += v
+				cols[j] += v
+			}
+			if sum != 1 {
+				t.Errorf("PermutationMatrix(%d): row %d sums to %v, want 1", n, i, sum)
+			}
+		}
+		for j, sum := range cols {
+			if sum != 1 {
+				t.Errorf("PermutationMatrix(%d): column %d sums to %v, want 1", n, j, sum)
+			}
+		}
+	}
+
+	// The matrix for a seed has its ones where Perm with the same seed puts them.
+	m := PermutationMatrix(newTestSource(7), 10)
+	for i, j := range Perm(newTestSource(7), 10) {
+		if m[i][j] != 1 {
+			t.Errorf("row %d has no 1 in column %d chosen by Perm with the same seed", i, j)
+		}
+	}
+}
+
+func TestPermIntoMatchesPerm(t *testing.T) {
+	want := Perm(newTestSource(7), 40)
+	dst := make([]int, 40)
+	for i := range dst {
+		dst[i] = -1 // PermInto must overwrite whatever dst held
+	}
+	PermInto(newTestSource(7), dst)
+	for i := range want {
+		if dst[i] != want[i] {
+			t.Fatalf("PermInto = %v, want Perm's %v", dst, want)
+		}
+	}
+	src := newTestSource(1)
+	if allocs := testing.AllocsPerRun(100, func() { PermInto(src, dst) }); allocs != 0 {
+		t.Errorf("PermInto allocated %v times per call, want 0", allocs)
+	}
+}
+
+func TestCombination(t *testing.T) {
+	src := newTestSource(1)
+	const n, k, trials = 6, 3, 100000 // 20 combinations
+	counts := make(map[[k]int]int)
+	for i := 0; i < trials; i++ {
+		c := Combination(src, n, k)
+		if len(c) != k {
+			t.Fatalf("Combination(%d, %d) = %v, want %d elements", n, k, c, k)
+		}
+		for j, v := range c {
+			if v < 0 || v >= n || j > 0 && v <= c[j-1] {
+				t.Fatalf("Combination(%d, %d) = %v, not strictly increasing in [0, %d)", n, k, c, n)
+			}
+		}
+		counts[[k]int{c[0], c[1], c[2]}]++
+	}
+	if len(counts) != 20 {
+		t.Errorf("Combination(%d, %d) produced %d distinct combinations, want 20", n, k, len(counts))
+	}
+	for c, got := range counts {
+		if want := trials / 20; got < want*94/100 || got > want*106/100 {
+			t.Errorf("combination %v drawn %d times, want about %d", c, got, want)
+		}
+	}
+	if c := Combination(src, 4, 0); len(c) != 0 {
+		t.Errorf("Combination(4, 0) = %v", c)
+	}
+	if c := Combination(src, 4, 4); len(c) != 4 || c[0] != 0 || c[3] != 3 {
+		t.Errorf("Combination(4, 4) = %v, want [0 1 2 3]", c)
+	}
+	for _, a := range [][2]int{{3, 4}, {3, -1}, {-1, 0}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Combination(%d, %d) did not panic", a[0], a[1])
+				}
+			}()
+			Combination(src, a[0], a[1])
+		}()
+	}
+}
+
+func TestRandomPartition(t *testing.T) {
+	src := newTestSource(1)
+	// There are C(4+3-1, 3-1) = 15 compositions of 4 into 3 parts.
+	const trials = 60000
+	counts := make(map[[3]int64]int)
+	for i := 0; i < trials; i++ {
+		p := RandomPartition(src, 4, 3)
+		if len(p) != 3 || p[0]+p[1]+p[2] != 4 || p[0] < 0 || p[1] < 0 || p[2] < 0 {
+			t.Fatalf("RandomPartition(4, 3) = %v", p)
+		}
+		counts[[3]int64{p[0], p[1], p[2]}]++
+	}
+	if len(counts) != 15 {
+		t.Errorf("RandomPartition(4, 3) produced %d distinct compositions, want 15", len(counts))
+	}
+	for c, got := range counts {
+		if want := trials / 15; got < want*93/100 || got > want*107/100 {
+			t.Errorf("composition %v drawn %d times, want about %d", c, got, want)
+		}
+	}
+
+	for _, c := range []struct {
+		total int64
+		parts int
+	}{{0, 5}, {7, 1}, {1e6, 100}, {math.MaxInt64 - 10, 11}} {
+		sum := int64(0)
+		for _, v := range RandomPartition(src, c.total, c.parts) {
+			if v < 0 {
+				t.Fatalf("RandomPartition(%d, %d) has a negative part", c.total, c.parts)
+			}
+			sum += v
+		}
+		if sum != c.total {
+			t.Errorf("RandomPartition(%d, %d) sums to %d", c.total, c.parts, sum)
+		}
+	}
+
+	for _, c := range []struct {
+		total int64
+		parts int
+	}{{5, 0}, {-1, 2}, {math.MaxInt64, 2}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("RandomPartition(%d, %d) did not panic", c.total, c.parts)
+				}
+			}()
+			RandomPartition(src, c.total, c.parts)
+		}()
+	}
+}
+
+func TestRandomRotation(t *testing.T) {
+	src := newTestSource(1)
+	const n, trials = 7, 70000
+	counts := make([]int, n)
+	for i := 0; i < trials; i++ {
+		counts[RandomRotation(src, n)]++
+	}
+	for k, c := range counts {
+		if c < trials/n*95/100 || c > trials/n*105/100 {
+			t.Errorf("rotation %d drawn %d times, want about %d", k, c, trials/n)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("RandomRotation(0) did not panic")
+		}
+	}()
+	RandomRotation(src, 0)
+}
+
+func TestRotateSlice(t *testing.T) {
+	orig := []string{"a", "b", "c", "d", "e"}
+	for seed := uint64(1); seed <= 20; seed++ {
+		s := append([]string(nil), orig...)
+		RotateSlice(newTestSource(seed), s)
+		// s must equal orig rotated left by the offset RandomRotation draws from the same seed.
+		k := RandomRotation(newTestSource(seed), len(orig))
+		for i := range s {
+			if s[i] != orig[(i+k)%len(orig)] {
+				t.Fatalf("seed %d: RotateSlice gave %v, want %v rotated left by %d", seed, s, orig, k)
+			}
+		}
+	}
+	RotateSlice(newTestSource(1), []int(nil))
+}
@@ -0,0 +1,102 @@
+package milkrandom
+
+import "math"
+
+// BrownianPath generates a discretized standard Brownian motion with the given number of steps
+// of length dt. The returned path has steps+1 points and starts at 0. Each increment is
+// sqrt(dt) times a standard normal value. It panics if steps < 0 or dt < 0.
+func BrownianPath(src Source, steps int, dt float64) []float64 {
+	if steps < 0 {
+		panic("milkrandom: argument steps to BrownianPath is < 0")
+	}
+	if !(dt >= 0) {
+		panic("milkrandom: argument dt to BrownianPath is < 0")
+	}
+	path := make([]float64, steps+1)
+	scale := math.Sqrt(dt)
+	for i := 1; i <= steps; i++ {
+		path[i] = path[i-1] + scale*NormFloat64(src)
+	}
+	return path
+}
+
+// PoissonProcess generates the arrival times of a homogeneous Poisson process, whose inter-arrival
+// times are exponentially distributed with mean 1/rate. It is a building block for discrete-event
+// simulations driven by a simulation clock.
+type PoissonProcess struct {
+	src  Source
+	rate float64
+}
+
+// NewPoissonProcess creates a new PoissonProcess with the given rate of events per unit time that
+// draws from src. It panics if rate is not positive and finite.
+func NewPoissonProcess(src Source, rate float64) *PoissonProcess {
+	if !(rate > 0) || math.IsInf(rate, 1) {
+		panic("milkrandom: argument to NewPoissonProcess must be positive and finite")
+	}
+	return &PoissonProcess{src: src, rate: rate}
+}
+
+// NextArrival returns the time of the next event after currentTime.
+func (p *PoissonProcess) NextArrival(currentTime float64) float64 {
+	return currentTime + ExpFloat64(p.src)/p.rate
+}
+
+// Rate returns the rate of events per unit time.
+func (p *PoissonProcess) Rate() float64 {
+	return p.rate
+}
+
+// FractionalBrownian generates a discretized fractional Brownian motion with Hurst exponent hurst
+// over steps unit time steps. The returned path has steps+1 points and starts at 0. Its increments
+// are exact fractional Gaussian noise with unit variance, generated by Hosking's method, which
+// conditions each increment on all earlier ones through the Durbin–Levinson recursion in O(steps²)
+// time. Increments are positively correlated for hurst > 0.5, negatively for hurst < 0.5, and
+// independent, as in BrownianPath, for hurst = 0.5. It panics if steps < 0 or hurst is not in (0, 1).
+func FractionalBrownian(src Source, steps int, hurst float64) []float64 {
+	if steps < 0 {
+		panic("milkrandom: argument steps to FractionalBrownian is < 0")
+	}
+	if !(hurst > 0 && hurst < 1) {
+		panic("milkrandom: argument hurst to FractionalBrownian is not in (0, 1)")
+	}
+	// gamma returns the autocovariance of fractional Gaussian noise at lag k.
+	h2 := 2 * hurst
+	gamma := func(k int) float64 {
+		x := float64(k)
+		return (math.Pow(x+1, h2) - 2*math.Pow(x, h2) + math.Pow(math.Abs(x-1), h2)) / 2
+	}
+	path := make([]float64, steps+1)
+	if steps == 0 {
+		return path
+	}
+	noise := make([]float64, steps)
+	phi := make([]float64, 0, steps)
+	prev := make([]float64, 0, steps)
+	v := 1.0
+	noise[0] = NormFloat64(src)
+	for n := 1; n < steps; n++ {
+		// Extend the partial autocorrelations phi[j-1] = φ(n, j) from order n-1 to order n.
+		num := gamma(n)
+		for j := 1; j < n; j++ {
+			num -= phi[j-1] * gamma(n-j)
+		}
+		pnn := num / v
+		prev = append(prev[:0], phi...)
+		for j := 1; j < n; j++ {
+			phi[j-1] = prev[j-1] - pnn*prev[n-j-1]
+		}
+		phi = append(phi, pnn)
+		v *= 1 - pnn*pnn
+
+		mean := 0.0
+		for j := 1; j <= n; j++ {
+			mean += phi[j-1] * noise[n-j]
+		}
+		noise[n] = mean + math.Sqrt(v)*NormFloat64(src)
+	}
+	for i, x := range noise {
+		path[i+1] = path[i] + x
+	}
+	return path
+}
@@ -0,0 +1,131 @@
+package milkrandom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBrownianPath(t *testing.T) {
+	src := newTestSource(1)
+	const steps, dt, paths = 50, 0.02, 20000
+	var sum, sumSq float64
+	for i := 0; i < paths; i++ {
+		path := BrownianPath(src, steps, dt)
+		if len(path) != steps+1 {
+			t.Fatalf("len(path) = %d, want %d", len(path), steps+1)
+		}
+		if path[0] != 0 {
+			t.Fatalf("path starts at %v, want 0", path[0])
+		}
+		end := path[steps]
+		sum += end
+		sumSq += end * end
+	}
+	mean := sum / paths
+	variance := sumSq/paths - mean*mean
+	if math.Abs(mean) > 0.03 {
+		t.Errorf("mean terminal value = %v, want about 0", mean)
+	}
+	if want := steps * dt; math.Abs(variance-want) > 0.03*want {
+		t.Errorf("variance of terminal value = %v, want about %v", variance, want)
+	}
+}
+
+func TestPoissonProcessInterArrivals(t *testing.T) {
+	const rate, n = 2.5, 100000
+	p := NewPoissonProcess(newTestSource(1), rate)
+	if p.Rate() != rate {
+		t.Errorf("Rate() = %v, want %v", p.Rate(), rate)
+	}
+	now, sum := 0.0, 0.0
+	above := map[float64]int{0.1: 0, 0.5: 0, 1: 0, 2: 0}
+	for i := 0; i < n; i++ {
+		next := p.NextArrival(now)
+		gap := next - now
+		if !(gap > 0) {
+			t.Fatalf("NextArrival(%v) = %v, not after the current time", now, next)
+		}
+		sum += gap
+		for x := range above {
+			if gap > x {
+				above[x]++
+			}
+		}
+		now = next
+	}
+	if mean := sum / n; math.Abs(mean-1/rate) > 0.01/rate {
+		t.Errorf("mean inter-arrival time = %v, want %v", mean, 1/rate)
+	}
+	// An exponential inter-arrival time exceeds x with probability exp(-rate*x).
+	for x, c := range above {
+		if got, want := float64(c)/n, math.Exp(-rate*x); math.Abs(got-want) > 0.005 {
+			t.Errorf("P(gap > %v) = %v, want %v", x, got, want)
+		}
+	}
+
+	for _, r := range []float64{0, -1, math.Inf(1), math.NaN()} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewPoissonProcess(%v) did not panic", r)
+				}
+			}()
+			NewPoissonProcess(newTestSource(1), r)
+		}()
+	}
+}
+
+func TestFractionalBrownian(t *testing.T) {
+	src := newTestSource(1)
+	const steps, paths = 64, 2000
+	for _, hurst := range []float64{0.3, 0.5, 0.8} {
+		// Autocovariance of the increments at lags 0, 1 and 5, and the variance of the endpoint.
+		var cov [3]float64
+		lags := [3]int{0, 1, 5}
+		end := 0.0
+		for p := 0; p < paths; p++ {
+			path := FractionalBrownian(src, steps, hurst)
+			if len(path) != steps+1 || path[0] != 0 {
+				t.Fatalf("FractionalBrownian returned %d points starting at %v", len(path), path[0])
+			}
+			for l, lag := range lags {
+				for i := 1; i+lag <= steps; i++ {
+					cov[l] += (path[i] - path[i-1]) * (path[i+lag] - path[i+lag-1]) / float64((steps-lag)*paths)
+				}
+			}
+			end += path[steps] * path[steps] / paths
+		}
+		h2 := 2 * hurst
+		for l, lag := range lags {
+			k := float64(lag)
+			want := (math.Pow(k+1, h2) - 2*math.Pow(k, h2) + math.Pow(math.Abs(k-1), h2)) / 2
+			if math.Abs(cov[l]-want) > 0.03 {
+				t.Errorf("hurst %v: increment autocovariance at lag %d = %v, want %v", hurst, lag, cov[l], want)
+			}
+		}
+		if want := math.Pow(steps, h2); math.Abs(end/want-1) > 0.1 {
+			t.Errorf("hurst %v: variance of the endpoint = %v, want %v", hurst, end, want)
+		}
+	}
+
+	a := FractionalBrownian(newTestSource(7), 50, 0.7)
+	b := FractionalBrownian(newTestSource(7), 50, 0.7)
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("point %d differs between sources with the same seed", i)
+		}
+	}
+	if p := FractionalBrownian(src, 0, 0.7); len(p) != 1 || p[0] != 0 {
+		t.Errorf("FractionalBrownian with no steps = %v, want [0]", p)
+	}
+	for _, h := range []float64{0, 1, -0.5, math.NaN()} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("FractionalBrownian(hurst %v) did not panic", h)
+				}
+			}()
+			FractionalBrownian(src, 10, h)
+		}()
+	}
+}
@@ -0,0 +1,52 @@
+package milkrandom
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimited wraps a Source and caps the number of draws per second. Calls to Uint64 block
+// until the next draw is allowed. RateLimited is safe for concurrent use as long as the
+// wrapped source is only accessed through it.
+type RateLimited struct {
+	mu       sync.Mutex
+	src      Source
+	interval time.Duration
+	next     time.Time
+}
+
+// NewRateLimited creates a new RateLimited that allows at most perSecond draws per second from src.
+// It panics if perSecond <= 0.
+func NewRateLimited(src Source, perSecond float64) *RateLimited {
+	r := &RateLimited{src: src}
+	r.SetRate(perSecond)
+	return r
+}
+
+// SetRate changes the maximum number of draws per second. Draws that are already waiting
+// keep their scheduled time. It panics if perSecond <= 0.
+func (r *RateLimited) SetRate(perSecond float64) {
+	if perSecond <= 0 {
+		panic("milkrandom: argument to SetRate is <= 0")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interval = time.Duration(float64(time.Second) / perSecond)
+}
+
+// Uint64 waits until a draw is allowed and then generates a random 64-bit unsigned integer from the wrapped source.
+func (r *RateLimited) Uint64() uint64 {
+	r.mu.Lock()
+	at := time.Now()
+	if r.next.After(at) {
+		at = r.next
+	}
+	r.next = at.Add(r.interval)
+	r.mu.Unlock()
+
+	time.Sleep(time.Until(at))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.src.Uint64()
+}
@@ -0,0 +1,36 @@
+package milkrandom
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimited(t *testing.T) {
+	const rate = 100.0
+	const window = 200 * time.Millisecond
+	r := NewRateLimited(newTestSource(1), rate)
+	var draws int64
+	var wg sync.WaitGroup
+	deadline := time.Now().Add(window)
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for time.Now().Before(deadline) {
+				r.Uint64()
+				atomic.AddInt64(&draws, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	// Each goroutine may finish one draw that was scheduled before the deadline.
+	limit := int64(rate*window.Seconds()) + 1 + 4
+	if draws > limit {
+		t.Errorf("%d draws in %v at %v per second, want at most %d", draws, window, rate, limit)
+	}
+	if draws < limit/2 {
+		t.Errorf("only %d draws in %v at %v per second", draws, window, rate)
+	}
+}
@@ -0,0 +1,50 @@
+package milkrandom
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// Reader returns an io.Reader that fills buffers with bytes from src. Each value from src is
+// written in little-endian order, and unused bytes are kept for the next call, so the byte
+// stream does not depend on how reads are sized. Reads always fill the buffer completely and
+// never return an error.
+//
+// The returned reader is NOT cryptographically secure. It is intended for deterministic tests
+// of code that accepts an io.Reader such as crypto/rand.Reader, and must never be used to
+// generate keys, nonces or other secrets.
+func Reader(src Source) io.Reader {
+	return &reader{src: src}
+}
+
+type reader struct {
+	src   Source
+	buf   [8]byte
+	avail int // number of unread bytes at the end of buf
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if r.avail == 0 {
+			binary.LittleEndian.PutUint64(r.buf[:], r.src.Uint64())
+			r.avail = len(r.buf)
+		}
+		c := copy(p[n:], r.buf[len(r.buf)-r.avail:])
+		r.avail -= c
+		n += c
+	}
+	return n, nil
+}
+
+// GenerateBytes writes size random bytes from src to w in chunks. The bytes are the same as
+// those produced by reading from Reader(src), so a given source state always yields
+// byte-identical output. Any error returned by w is returned unchanged.
+func GenerateBytes(src Source, w io.Writer, size int64) error {
+	if size < 0 {
+		return errors.New("milkrandom: argument size to GenerateBytes is < 0")
+	}
+	_, err := io.CopyN(w, Reader(src), size)
+	return err
+}
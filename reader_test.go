@@ -0,0 +1,86 @@
+package milkrandom
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestReaderReproducible(t *testing.T) {
+	whole := make([]byte, 1000)
+	if n, err := Reader(newTestSource(1)).Read(whole); n != len(whole) || err != nil {
+		t.Fatalf("Read = %d, %v; want %d, nil", n, err, len(whole))
+	}
+	// Reading the same stream in odd-sized pieces yields the same bytes.
+	r := Reader(newTestSource(1))
+	var pieces []byte
+	for _, size := range []int{1, 3, 7, 8, 13, 64, 904} {
+		buf := make([]byte, size)
+		if n, err := r.Read(buf); n != size || err != nil {
+			t.Fatalf("Read(%d bytes) = %d, %v", size, n, err)
+		}
+		pieces = append(pieces, buf...)
+	}
+	if !bytes.Equal(whole, pieces) {
+		t.Error("bytes depend on how reads are sized")
+	}
+	var want [8]byte
+	binary.LittleEndian.PutUint64(want[:], newTestSource(1).Uint64())
+	if !bytes.Equal(whole[:8], want[:]) {
+		t.Errorf("first bytes %x, want the first value in little-endian order %x", whole[:8], want)
+	}
+}
+
+func TestGenerateBytesReproducible(t *testing.T) {
+	// An odd size larger than any internal chunk exercises the partial final chunk.
+	const size = 1<<20 + 3
+	var a, b bytes.Buffer
+	if err := GenerateBytes(newTestSource(1), &a, size); err != nil {
+		t.Fatal(err)
+	}
+	if err := GenerateBytes(newTestSource(1), &b, size); err != nil {
+		t.Fatal(err)
+	}
+	if a.Len() != size {
+		t.Fatalf("GenerateBytes wrote %d bytes, want %d", a.Len(), size)
+	}
+	if !bytes.Equal(a.Bytes(), b.Bytes()) {
+		t.Error("GenerateBytes output differs for sources with the same seed")
+	}
+	want := make([]byte, size)
+	if _, err := io.ReadFull(Reader(newTestSource(1)), want); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(a.Bytes(), want) {
+		t.Error("GenerateBytes output differs from reading Reader")
+	}
+	if err := GenerateBytes(newTestSource(1), &a, -1); err == nil {
+		t.Error("GenerateBytes accepted a negative size")
+	}
+}
+
+// failingWriter accepts n bytes and then fails every write with err.
+type failingWriter struct {
+	n   int
+	err error
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) <= w.n {
+		w.n -= len(p)
+		return len(p), nil
+	}
+	n := w.n
+	w.n = 0
+	return n, w.err
+}
+
+func TestGenerateBytesWriterError(t *testing.T) {
+	errWrite := errors.New("disk full")
+	w := &failingWriter{n: 100, err: errWrite}
+	if err := GenerateBytes(newTestSource(1), w, 1000); err != errWrite {
+		t.Errorf("GenerateBytes = %v, want the writer's error %v", err, errWrite)
+	}
+}
@@ -0,0 +1,78 @@
+package milkrandom
+
+import (
+	"container/heap"
+	"math"
+)
+
+// WeightedReservoir keeps a weighted random sample of at most k items from a stream of unknown
+// length using the A-Res algorithm of Efraimidis and Spirakis. Each item receives the key
+// u^(1/weight) for a uniform u and the k items with the largest keys are kept in a min-heap,
+// so an item's chance of being in the sample grows with its weight.
+type WeightedReservoir[T any] struct {
+	src Source
+	k   int
+	h   reservoirHeap[T]
+}
+
+// NewWeightedReservoir creates a new WeightedReservoir that keeps up to k items, drawing keys
+// from src. It panics if k < 0.
+func NewWeightedReservoir[T any](src Source, k int) *WeightedReservoir[T] {
+	if k < 0 {
+		panic("milkrandom: argument to NewWeightedReservoir is < 0")
+	}
+	return &WeightedReservoir[T]{src: src, k: k, h: make(reservoirHeap[T], 0, k)}
+}
+
+// Add offers item with the given weight to the reservoir. Items with weight 0 are never kept.
+// It panics if weight is negative or not finite.
+func (r *WeightedReservoir[T]) Add(item T, weight float64) {
+	if !(weight >= 0) || math.IsInf(weight, 1) {
+		panic("milkrandom: weight passed to WeightedReservoir.Add must be finite and non-negative")
+	}
+	if weight == 0 || r.k == 0 {
+		return
+	}
+	// log(u)/weight orders items exactly like u^(1/weight) without underflowing for small weights.
+	key := math.Log(float64Open(r.src)) / weight
+	if len(r.h) < r.k {
+		heap.Push(&r.h, reservoirItem[T]{key: key, item: item})
+	} else if key > r.h[0].key {
+		r.h[0] = reservoirItem[T]{key: key, item: item}
+		heap.Fix(&r.h, 0)
+	}
+}
+
+// Len returns the number of items currently in the sample, which is at most k.
+func (r *WeightedReservoir[T]) Len() int {
+	return len(r.h)
+}
+
+// Sample returns a copy of the items currently in the sample, in no particular order.
+func (r *WeightedReservoir[T]) Sample() []T {
+	out := make([]T, len(r.h))
+	for i, e := range r.h {
+		out[i] = e.item
+	}
+	return out
+}
+
+// reservoirItem pairs an item with its A-Res key.
+type reservoirItem[T any] struct {
+	key  float64
+	item T
+}
+
+// reservoirHeap is a min-heap of reservoirItem ordered by key.
+type reservoirHeap[T any] []reservoirItem[T]
+
+func (h reservoirHeap[T]) Len() int            { return len(h) }
+func (h reservoirHeap[T]) Less(i, j int) bool  { return h[i].key < h[j].key }
+func (h reservoirHeap[T]) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *reservoirHeap[T]) Push(x interface{}) { *h = append(*h, x.(reservoirItem[T])) }
+func (h *reservoirHeap[T]) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
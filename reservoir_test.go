@@ -0,0 +1,71 @@
+package milkrandom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWeightedReservoirInclusion(t *testing.T) {
+	weights := []float64{1, 2, 3, 4, 0}
+	total := 10.0
+	// A-Res keeps the same items as drawing k items one by one without replacement, each
+	// with probability proportional to its weight among those left. For k = 1 that is
+	// w/total; for k = 2 an item is drawn either first or second.
+	want := map[int][]float64{1: make([]float64, len(weights)), 2: make([]float64, len(weights))}
+	for i, wi := range weights {
+		want[1][i] = wi / total
+		want[2][i] = wi / total
+		for j, wj := range weights {
+			if j != i {
+				want[2][i] += wj / total * wi / (total - wj)
+			}
+		}
+	}
+
+	src := newTestSource(1)
+	const trials = 50000
+	for k, probs := range want {
+		counts := make([]int, len(weights))
+		for trial := 0; trial < trials; trial++ {
+			r := NewWeightedReservoir[int](src, k)
+			for i, w := range weights {
+				r.Add(i, w)
+				if r.Len() > k {
+					t.Fatalf("k=%d: reservoir holds %d items", k, r.Len())
+				}
+			}
+			sample := r.Sample()
+			if len(sample) != k {
+				t.Fatalf("k=%d: sample %v has %d items", k, sample, len(sample))
+			}
+			for _, i := range sample {
+				counts[i]++
+			}
+		}
+		for i, p := range probs {
+			if got := float64(counts[i]) / trials; math.Abs(got-p) > 0.01 {
+				t.Errorf("k=%d: item with weight %v included with frequency %v, want %v", k, weights[i], got, p)
+			}
+		}
+	}
+}
+
+func TestWeightedReservoirSmallStream(t *testing.T) {
+	r := NewWeightedReservoir[string](newTestSource(1), 5)
+	r.Add("a", 1)
+	r.Add("b", 1e-300)
+	r.Add("c", 0)
+	if r.Len() != 2 {
+		t.Errorf("reservoir of 5 holds %d items after two weighted and one zero-weight item, want 2", r.Len())
+	}
+	for _, w := range []float64{-1, math.NaN(), math.Inf(1)} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Add with weight %v did not panic", w)
+				}
+			}()
+			r.Add("d", w)
+		}()
+	}
+}
@@ -0,0 +1,14 @@
+package milkrandom
+
+import "math"
+
+// RandomRound rounds x to one of its two neighbouring integers. It rounds up with
+// probability equal to the fractional part of x and down otherwise, so the expected
+// value of the result equals x.
+func RandomRound(src Source, x float64) int64 {
+	floor := math.Floor(x)
+	if float64From(src) < x-floor {
+		return int64(floor) + 1
+	}
+	return int64(floor)
+}
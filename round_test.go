@@ -0,0 +1,33 @@
+package milkrandom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRandomRound(t *testing.T) {
+	src := newTestSource(1)
+	const n = 100000
+	sum := 0.0
+	for i := 0; i < n; i++ {
+		v := RandomRound(src, 2.3)
+		if v != 2 && v != 3 {
+			t.Fatalf("RandomRound(2.3) = %d, want 2 or 3", v)
+		}
+		sum += float64(v)
+	}
+	if mean := sum / n; math.Abs(mean-2.3) > 0.01 {
+		t.Errorf("mean of RandomRound(2.3) = %v, want about 2.3", mean)
+	}
+}
+
+func TestRandomRoundInteger(t *testing.T) {
+	src := newTestSource(1)
+	for _, x := range []float64{-3, 0, 7} {
+		for i := 0; i < 100; i++ {
+			if got := RandomRound(src, x); got != int64(x) {
+				t.Fatalf("RandomRound(%v) = %d, want %d", x, got, int64(x))
+			}
+		}
+	}
+}
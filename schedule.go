@@ -0,0 +1,54 @@
+package milkrandom
+
+import "math"
+
+// Schedule returns a random interleaving of steps scheduling decisions among n goroutines,
+// each entry being the ID in [0, n) of the goroutine that runs at that step. Every step picks
+// uniformly and independently, so replaying a race scenario only needs the seed of src.
+// It panics if n <= 0 or steps < 0.
+func Schedule(src Source, n, steps int) []int {
+	return FairSchedule(src, n, steps, 0)
+}
+
+// FairSchedule is like Schedule but biases each step towards goroutines that have run less so
+// far. A goroutine that has run k steps more than the least-run one is chosen with weight
+// 1/(1+fairness*k), so fairness 0 gives the uniform interleavings of Schedule and larger values
+// give increasingly round-robin-like ones. It panics if n <= 0, steps < 0 or fairness is
+// negative or not finite.
+func FairSchedule(src Source, n, steps int, fairness float64) []int {
+	if n <= 0 {
+		panic("milkrandom: argument n to Schedule is <= 0")
+	}
+	if steps < 0 {
+		panic("milkrandom: argument steps to Schedule is < 0")
+	}
+	if !(fairness >= 0) || math.IsInf(fairness, 1) {
+		panic("milkrandom: argument fairness to FairSchedule must be finite and non-negative")
+	}
+	sched := make([]int, steps)
+	if fairness == 0 {
+		for i := range sched {
+			sched[i] = intn(src, n)
+		}
+		return sched
+	}
+	counts := make([]int, n)
+	cum := make([]float64, n)
+	for s := range sched {
+		least := counts[0]
+		for _, c := range counts {
+			if c < least {
+				least = c
+			}
+		}
+		total := 0.0
+		for i, c := range counts {
+			total += 1 / (1 + fairness*float64(c-least))
+			cum[i] = total
+		}
+		g := pickCumulative(src, cum)
+		counts[g]++
+		sched[s] = g
+	}
+	return sched
+}
@@ -0,0 +1,79 @@
+package milkrandom
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestSchedule(t *testing.T) {
+	const n, steps = 4, 40000
+	s := Schedule(newTestSource(1), n, steps)
+	if len(s) != steps {
+		t.Fatalf("Schedule(%d, %d) has %d steps", n, steps, len(s))
+	}
+	counts := make([]int, n)
+	for _, g := range s {
+		if g < 0 || g >= n {
+			t.Fatalf("Schedule(%d, %d) contains goroutine %d", n, steps, g)
+		}
+		counts[g]++
+	}
+	for g, c := range counts {
+		if c < steps/n*95/100 || c > steps/n*105/100 {
+			t.Errorf("goroutine %d scheduled %d times, want about %d", g, c, steps/n)
+		}
+	}
+	if a, b := Schedule(newTestSource(7), n, 100), Schedule(newTestSource(7), n, 100); fmt.Sprint(a) != fmt.Sprint(b) {
+		t.Error("Schedule differs between sources with the same seed")
+	}
+	if len(Schedule(newTestSource(1), n, 0)) != 0 {
+		t.Error("Schedule with 0 steps is not empty")
+	}
+}
+
+func TestFairSchedule(t *testing.T) {
+	const n, steps = 5, 1000
+	// The largest lead of any goroutine over the least-run one, with and without fairness.
+	spread := func(fairness float64) int {
+		counts := make([]int, n)
+		worst := 0
+		for _, g := range FairSchedule(newTestSource(1), n, steps, fairness) {
+			counts[g]++
+			lo, hi := counts[0], counts[0]
+			for _, c := range counts {
+				if c < lo {
+					lo = c
+				}
+				if c > hi {
+					hi = c
+				}
+			}
+			if hi-lo > worst {
+				worst = hi - lo
+			}
+		}
+		return worst
+	}
+	if uniform, fair := spread(0), spread(100); fair >= uniform || fair > 3 {
+		t.Errorf("largest lead is %d with fairness 100 and %d without, want a small lead with fairness", fair, uniform)
+	}
+	a := FairSchedule(newTestSource(7), n, 100, 2)
+	b := FairSchedule(newTestSource(7), n, 100, 2)
+	if fmt.Sprint(a) != fmt.Sprint(b) {
+		t.Error("FairSchedule differs between sources with the same seed")
+	}
+	for _, c := range []struct {
+		n, steps int
+		fairness float64
+	}{{0, 10, 0}, {2, -1, 0}, {2, 10, -1}, {2, 10, math.NaN()}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("FairSchedule(%d, %d, %v) did not panic", c.n, c.steps, c.fairness)
+				}
+			}()
+			FairSchedule(newTestSource(1), c.n, c.steps, c.fairness)
+		}()
+	}
+}
@@ -0,0 +1,109 @@
+package milkrandom
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// FirstSuccess iterates over items in order, accepting each one with probability p(item),
+// and returns the first accepted item. It returns false if no item is accepted.
+func FirstSuccess[T any](src Source, items []T, p func(T) float64) (T, bool) {
+	for _, item := range items {
+		if float64From(src) < p(item) {
+			return item, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// RandomKey returns a uniformly random key of m. It returns false if m is empty.
+// Keys are sorted with less before selection, so the result does not depend on Go's randomized
+// map iteration order and is reproducible for a given source state. less must be a strict
+// ordering that tells every pair of distinct keys apart.
+func RandomKey[K comparable, V any](src Source, m map[K]V, less func(a, b K) bool) (K, bool) {
+	if len(m) == 0 {
+		var zero K
+		return zero, false
+	}
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return less(keys[i], keys[j]) })
+	return keys[intn(src, len(keys))], true
+}
+
+// Until repeatedly generates values with gen until one satisfies pred, trying at most maxTries times.
+// It returns the first accepted value, or false if every try was rejected.
+func Until[T any](src Source, gen func(Source) T, pred func(T) bool, maxTries int) (T, bool) {
+	for i := 0; i < maxTries; i++ {
+		if v := gen(src); pred(v) {
+			return v, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// TopK returns a uniformly random subset of k items. Each item is given a random key and the
+// k items with the largest keys are kept in a min-heap, so only O(k) extra memory is used.
+// The result is ordered from largest to smallest key. It panics if k < 0 or k > len(items).
+func TopK[T any](src Source, items []T, k int) []T {
+	if k < 0 || k > len(items) {
+		panic("milkrandom: argument k to TopK is not in [0, len(items)]")
+	}
+	h := make(keyedHeap, 0, k)
+	for i := range items {
+		key := src.Uint64()
+		if len(h) < k {
+			heap.Push(&h, keyedIndex{key: key, index: i})
+		} else if k > 0 && key > h[0].key {
+			h[0] = keyedIndex{key: key, index: i}
+			heap.Fix(&h, 0)
+		}
+	}
+	out := make([]T, len(h))
+	for i := len(h) - 1; i >= 0; i-- {
+		out[i] = items[heap.Pop(&h).(keyedIndex).index]
+	}
+	return out
+}
+
+// keyedIndex pairs an index with its random sort key.
+type keyedIndex struct {
+	key   uint64
+	index int
+}
+
+// keyedHeap is a min-heap of keyedIndex ordered by key.
+type keyedHeap []keyedIndex
+
+func (h keyedHeap) Len() int            { return len(h) }
+func (h keyedHeap) Less(i, j int) bool  { return h[i].key < h[j].key }
+func (h keyedHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *keyedHeap) Push(x interface{}) { *h = append(*h, x.(keyedIndex)) }
+func (h *keyedHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// ChoiceExcluding returns a uniformly random item of items that is not in blocked. It makes
+// a single pass, keeping the k-th eligible item with probability 1/k, so no eligible set is
+// built. It returns false if every item is blocked or items is empty.
+func ChoiceExcluding[T comparable](src Source, items []T, blocked map[T]bool) (T, bool) {
+	var chosen T
+	eligible := 0
+	for _, it := range items {
+		if blocked[it] {
+			continue
+		}
+		eligible++
+		if intn(src, eligible) == 0 {
+			chosen = it
+		}
+	}
+	return chosen, eligible > 0
+}
@@ -0,0 +1,170 @@
+package milkrandom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFirstSuccessAcceptanceRate(t *testing.T) {
+	src := newTestSource(1)
+	probs := []float64{0.2, 0.5, 0.9}
+	items := []int{0, 1, 2}
+	offered := make([]int, len(probs))
+	accepted := make([]int, len(probs))
+	for i := 0; i < 100000; i++ {
+		idx, ok := FirstSuccess(src, items, func(i int) float64 {
+			offered[i]++
+			return probs[i]
+		})
+		if ok {
+			accepted[idx]++
+		}
+	}
+	for i, p := range probs {
+		if rate := float64(accepted[i]) / float64(offered[i]); math.Abs(rate-p) > 0.01 {
+			t.Errorf("item %d accepted with rate %v, want %v", i, rate, p)
+		}
+	}
+	if _, ok := FirstSuccess(src, items, func(int) float64 { return 0 }); ok {
+		t.Error("FirstSuccess accepted an item with probability 0")
+	}
+}
+
+func TestRandomKey(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}
+	first, ok := RandomKey(newTestSource(1), m, lessString)
+	if !ok {
+		t.Fatal("RandomKey on a non-empty map returned false")
+	}
+	for i := 0; i < 20; i++ {
+		if k, _ := RandomKey(newTestSource(1), m, lessString); k != first {
+			t.Fatalf("RandomKey with the same seed returned %q then %q", first, k)
+		}
+	}
+	src := newTestSource(2)
+	const draws = 40000
+	counts := make(map[string]int)
+	for i := 0; i < draws; i++ {
+		k, _ := RandomKey(src, m, lessString)
+		counts[k]++
+	}
+	for k := range m {
+		if c := counts[k]; math.Abs(float64(c)-draws/4) > 0.05*draws/4 {
+			t.Errorf("key %q drawn %d times, want about %d", k, c, draws/4)
+		}
+	}
+	if _, ok := RandomKey(src, map[string]int{}, lessString); ok {
+		t.Error("RandomKey on an empty map returned true")
+	}
+
+	// Keys need only be comparable; less supplies the order.
+	type point struct{ x, y int }
+	points := map[point]bool{{0, 0}: true, {0, 1}: true, {1, 0}: true}
+	byXY := func(a, b point) bool { return a.x < b.x || a.x == b.x && a.y < b.y }
+	a, _ := RandomKey(newTestSource(3), points, byXY)
+	b, _ := RandomKey(newTestSource(3), points, byXY)
+	if !points[a] || a != b {
+		t.Errorf("RandomKey on struct keys returned %v then %v", a, b)
+	}
+}
+
+func lessString(a, b string) bool { return a < b }
+
+func TestUntil(t *testing.T) {
+	src := newTestSource(1)
+	v, ok := Until(src, NormFloat64, func(x float64) bool { return x > -1 && x < 1 }, 100)
+	if !ok {
+		t.Fatal("Until found no value in (-1, 1) in 100 tries")
+	}
+	if v <= -1 || v >= 1 {
+		t.Errorf("Until returned %v, which does not satisfy the predicate", v)
+	}
+	tries := 0
+	_, ok = Until(src, NormFloat64, func(x float64) bool {
+		tries++
+		return false
+	}, 25)
+	if ok {
+		t.Error("Until reported success for an impossible predicate")
+	}
+	if tries != 25 {
+		t.Errorf("Until tried %d times, want maxTries = 25", tries)
+	}
+}
+
+func TestTopK(t *testing.T) {
+	const n, k, trials = 20, 5, 40000
+	items := make([]int, n)
+	for i := range items {
+		items[i] = i
+	}
+	src := newTestSource(1)
+	counts := make([]int, n)
+	for i := 0; i < trials; i++ {
+		got := TopK(src, items, k)
+		if len(got) != k {
+			t.Fatalf("TopK returned %d items, want %d", len(got), k)
+		}
+		seen := make(map[int]bool)
+		for _, v := range got {
+			if seen[v] {
+				t.Fatalf("TopK returned %d twice: %v", v, got)
+			}
+			seen[v] = true
+			counts[v]++
+		}
+	}
+	for v, c := range counts {
+		if p := float64(c) / trials; math.Abs(p-float64(k)/n) > 0.015 {
+			t.Errorf("item %d selected with frequency %v, want %v", v, p, float64(k)/n)
+		}
+	}
+	if got := TopK(src, items, 0); len(got) != 0 {
+		t.Errorf("TopK with k = 0 returned %v", got)
+	}
+	if got := TopK(src, items, n); len(got) != n {
+		t.Errorf("TopK with k = len(items) returned %d items", len(got))
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("TopK with k > len(items) did not panic")
+		}
+	}()
+	TopK(src, items, n+1)
+}
+
+func TestChoiceExcluding(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e", "f"}
+	blocked := map[string]bool{"b": true, "e": true, "z": true}
+	const trials = 40000
+	src := newTestSource(1)
+	counts := make(map[string]int)
+	for i := 0; i < trials; i++ {
+		v, ok := ChoiceExcluding(src, items, blocked)
+		if !ok {
+			t.Fatal("ChoiceExcluding reported no eligible item")
+		}
+		if blocked[v] {
+			t.Fatalf("ChoiceExcluding returned blocked item %q", v)
+		}
+		counts[v]++
+	}
+	for _, v := range []string{"a", "c", "d", "f"} {
+		if p := float64(counts[v]) / trials; math.Abs(p-0.25) > 0.01 {
+			t.Errorf("item %q chosen with frequency %v, want 0.25", v, p)
+		}
+	}
+	if v, ok := ChoiceExcluding(src, items, nil); !ok || v == "" {
+		t.Errorf("ChoiceExcluding with no blocklist = %q, %v", v, ok)
+	}
+	all := map[string]bool{}
+	for _, v := range items {
+		all[v] = true
+	}
+	if v, ok := ChoiceExcluding(src, items, all); ok || v != "" {
+		t.Errorf("ChoiceExcluding with every item blocked = %q, %v; want \"\", false", v, ok)
+	}
+	if _, ok := ChoiceExcluding(src, nil, blocked); ok {
+		t.Error("ChoiceExcluding on no items reported an eligible item")
+	}
+}
@@ -0,0 +1,38 @@
+package milkrandom
+
+import "math"
+
+// NextSkip returns the number of items to skip before the next selected item when each
+// item of a stream is selected independently with probability p. It draws a single
+// geometrically distributed value instead of one Bernoulli trial per item.
+func NextSkip(src Source, p float64) int64 {
+	if !(p > 0 && p <= 1) {
+		panic("milkrandom: argument to NextSkip is not in (0, 1]")
+	}
+	if p == 1 {
+		return 0
+	}
+	u := 1 - float64From(src) // in (0.0, 1.0], avoids log(0)
+	skip := math.Floor(math.Log(u) / math.Log1p(-p))
+	if skip >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(skip)
+}
+
+// CappedGeometric returns a geometrically distributed number of failures before the first success
+// in trials with success probability p, clamped to maxValue. Values above the cap are returned as
+// maxValue rather than redrawn, so maxValue carries the whole upper tail and smaller values keep
+// their geometric probabilities. It panics if p is not in (0, 1] or maxValue < 0.
+func CappedGeometric(src Source, p float64, maxValue int64) int64 {
+	if !(p > 0 && p <= 1) {
+		panic("milkrandom: argument p to CappedGeometric is not in (0, 1]")
+	}
+	if maxValue < 0 {
+		panic("milkrandom: argument maxValue to CappedGeometric is < 0")
+	}
+	if v := NextSkip(src, p); v < maxValue {
+		return v
+	}
+	return maxValue
+}
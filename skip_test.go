@@ -0,0 +1,65 @@
+package milkrandom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNextSkipDensity(t *testing.T) {
+	src := newTestSource(1)
+	for _, p := range []float64{0.5, 0.1, 0.001} {
+		const selections = 20000
+		var items int64
+		for i := 0; i < selections; i++ {
+			items += NextSkip(src, p) + 1
+		}
+		if density := selections / float64(items); math.Abs(density-p) > 0.03*p {
+			t.Errorf("p = %v: selection density = %v", p, density)
+		}
+	}
+	if got := NextSkip(src, 1); got != 0 {
+		t.Errorf("NextSkip(1) = %d, want 0", got)
+	}
+}
+
+func TestCappedGeometric(t *testing.T) {
+	const p, maxValue, n = 0.3, 5, 200000
+	src := newTestSource(1)
+	counts := make([]int, maxValue+1)
+	for i := 0; i < n; i++ {
+		v := CappedGeometric(src, p, maxValue)
+		if v < 0 || v > maxValue {
+			t.Fatalf("CappedGeometric(%v, %d) = %d, out of range", p, maxValue, v)
+		}
+		counts[v]++
+	}
+	// Below the cap, P(k) = (1-p)^k p; the cap holds the tail (1-p)^maxValue.
+	for k := 0; k <= maxValue; k++ {
+		want := math.Pow(1-p, float64(k)) * p
+		if k == maxValue {
+			want = math.Pow(1-p, maxValue)
+		}
+		if got := float64(counts[k]) / n; math.Abs(got-want) > 0.005 {
+			t.Errorf("P(%d) = %v, want %v", k, got, want)
+		}
+	}
+	if v := CappedGeometric(src, 0.01, 0); v != 0 {
+		t.Errorf("CappedGeometric with maxValue 0 = %d", v)
+	}
+	if v := CappedGeometric(src, 1, 10); v != 0 {
+		t.Errorf("CappedGeometric with p = 1 = %d, want 0", v)
+	}
+	for _, c := range []struct {
+		p        float64
+		maxValue int64
+	}{{0, 5}, {1.5, 5}, {math.NaN(), 5}, {0.5, -1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("CappedGeometric(%v, %d) did not panic", c.p, c.maxValue)
+				}
+			}()
+			CappedGeometric(src, c.p, c.maxValue)
+		}()
+	}
+}
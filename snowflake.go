@@ -0,0 +1,71 @@
+package milkrandom
+
+import (
+	"sync"
+	"time"
+)
+
+// Layout of the IDs produced by SnowflakeLike, from the most significant bit: one zero bit so IDs
+// stay positive as int64, a millisecond timestamp, the machine ID and a per-millisecond sequence.
+const (
+	snowflakeTimeBits     = 41 // about 69 years from snowflakeEpoch
+	snowflakeMachineBits  = 10
+	snowflakeSequenceBits = 12
+)
+
+// snowflakeEpoch is the start of the SnowflakeLike timestamp, 2020-01-01T00:00:00Z in Unix milliseconds.
+const snowflakeEpoch = 1577836800000
+
+// SnowflakeLike generates roughly time-sortable unique 64-bit IDs made of a millisecond timestamp,
+// a machine ID and a sequence number. The sequence of each new millisecond starts at a random
+// value drawn from the wrapped source in the lower half of its range and counts up from there, so
+// IDs from one generator are strictly increasing and never collide, while the starting point does
+// not reveal how many IDs were issued. SnowflakeLike is safe for concurrent use as long as the
+// wrapped source is only accessed through it.
+type SnowflakeLike struct {
+	mu      sync.Mutex
+	src     Source
+	now     func() int64 // current Unix time in milliseconds
+	machine uint64
+	lastMs  int64
+	seq     uint64
+}
+
+// NewSnowflakeLike creates a new SnowflakeLike for the given machine ID that draws sequence
+// starting points from src. It panics if machineID does not fit in 10 bits.
+func NewSnowflakeLike(src Source, machineID uint16) *SnowflakeLike {
+	if machineID >= 1<<snowflakeMachineBits {
+		panic("milkrandom: argument to NewSnowflakeLike does not fit in 10 bits")
+	}
+	now := func() int64 { return time.Now().UnixMilli() }
+	return &SnowflakeLike{src: src, now: now, machine: uint64(machineID), lastMs: -1}
+}
+
+// Next returns a new ID. It never waits: if the clock has gone backwards it keeps issuing IDs from
+// the last timestamp, and once the sequence space of that millisecond is used up it moves on to the
+// next millisecond ahead of the clock, so IDs keep increasing until the clock catches up. It panics
+// if the clock is before 2020 or beyond the range of the timestamp.
+func (s *SnowflakeLike) Next() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ms := s.now() - snowflakeEpoch
+	fresh := true
+	if ms <= s.lastMs {
+		ms = s.lastMs
+		if s.seq+1 < 1<<snowflakeSequenceBits {
+			fresh = false
+		} else {
+			ms++
+		}
+	}
+	if ms < 0 || ms >= 1<<snowflakeTimeBits {
+		panic("milkrandom: clock out of range for SnowflakeLike")
+	}
+	if fresh {
+		s.lastMs = ms
+		s.seq = s.src.Uint64() >> (64 - snowflakeSequenceBits + 1)
+	} else {
+		s.seq++
+	}
+	return uint64(ms)<<(snowflakeMachineBits+snowflakeSequenceBits) | s.machine<<snowflakeSequenceBits | s.seq
+}
@@ -0,0 +1,116 @@
+package milkrandom
+
+import (
+	"sync"
+	"testing"
+)
+
+// snowflakeFields splits an ID into its timestamp, machine ID and sequence number.
+func snowflakeFields(id uint64) (ms int64, machine, seq uint64) {
+	return int64(id >> (snowflakeMachineBits + snowflakeSequenceBits)),
+		id >> snowflakeSequenceBits & (1<<snowflakeMachineBits - 1),
+		id & (1<<snowflakeSequenceBits - 1)
+}
+
+func TestSnowflakeLike(t *testing.T) {
+	s := NewSnowflakeLike(newTestSource(1), 37)
+	const start = snowflakeEpoch + 1000
+	clock := int64(start)
+	s.now = func() int64 { return clock }
+
+	var last uint64
+	next := func() uint64 {
+		t.Helper()
+		id := s.Next()
+		if id <= last {
+			t.Fatalf("ID %#x is not above the previous ID %#x", id, last)
+		}
+		if _, machine, _ := snowflakeFields(id); machine != 37 {
+			t.Fatalf("ID %#x carries machine ID %d, want 37", id, machine)
+		}
+		last = id
+		return id
+	}
+
+	// Many IDs within one millisecond use up its sequence space and move on to the next.
+	for i := 0; i < 3*(1<<snowflakeSequenceBits); i++ {
+		next()
+	}
+	if ms, _, _ := snowflakeFields(last); ms <= 1000 {
+		t.Errorf("timestamp %d did not move past the exhausted millisecond 1000", ms)
+	}
+	// The clock goes backwards twice while the timestamp is ahead of it.
+	for _, c := range []int64{start + 1, start - 500, start - 900, start + 2} {
+		clock = c
+		for i := 0; i < 1<<snowflakeSequenceBits; i++ {
+			next()
+		}
+	}
+	// Once the clock catches up the timestamp follows it again.
+	clock = start + 100
+	if ms, _, _ := snowflakeFields(next()); ms != 1100 {
+		t.Errorf("timestamp %d, want the clock's 1100", ms)
+	}
+	clock = start + 101
+	if ms, _, seq := snowflakeFields(next()); ms != 1101 || seq >= 1<<(snowflakeSequenceBits-1) {
+		t.Errorf("first ID of a new millisecond has timestamp %d and sequence %d", ms, seq)
+	}
+}
+
+func TestSnowflakeLikeClockOutOfRange(t *testing.T) {
+	s := NewSnowflakeLike(newTestSource(1), 0)
+	var clock int64
+	s.now = func() int64 { return clock }
+	for _, c := range []int64{snowflakeEpoch - 1, snowflakeEpoch + 1<<snowflakeTimeBits} {
+		clock = c
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Next with the clock at %d did not panic", c)
+				}
+			}()
+			s.Next()
+		}()
+	}
+	// The failed calls left no trace, so the generator follows the clock once it is valid.
+	clock = snowflakeEpoch + 5
+	if ms, _, _ := snowflakeFields(s.Next()); ms != 5 {
+		t.Errorf("timestamp after failed calls = %d, want 5", ms)
+	}
+}
+
+func TestSnowflakeLikeConcurrent(t *testing.T) {
+	s := NewSnowflakeLike(newTestSource(1), 1)
+	const workers, perWorker = 4, 5000
+	ids := make([][]uint64, workers)
+	var wg sync.WaitGroup
+	for w := range ids {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				ids[w] = append(ids[w], s.Next())
+			}
+		}(w)
+	}
+	wg.Wait()
+	seen := make(map[uint64]bool)
+	for _, list := range ids {
+		for i, id := range list {
+			if seen[id] {
+				t.Fatalf("ID %#x issued twice", id)
+			}
+			seen[id] = true
+			if i > 0 && id <= list[i-1] {
+				t.Fatalf("ID %#x is not above the previous ID %#x from the same goroutine", id, list[i-1])
+			}
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("NewSnowflakeLike with an 11-bit machine ID did not panic")
+		}
+	}()
+	NewSnowflakeLike(newTestSource(1), 1<<snowflakeMachineBits)
+}
@@ -3,14 +3,19 @@ package splitmix64
 
 import (
 	"encoding/binary"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strconv"
 	"sync"
 	"time"
 )
 
 // SplitMix64 represents the state of a SplitMix64 random number generator.
 type SplitMix64 struct {
-	state uint64
+	state  uint64
+	seed   uint64 // last value passed to Seed, valid while seeded is set
+	seeded bool
 }
 
 // SafeSplitMix64 represents the state of a SplitMix64 random number generator with a mutex to make it safe for concurrent use.
@@ -19,20 +24,41 @@ type SafeSplitMix64 struct {
 	mu sync.Mutex
 }
 
+// Both generator types implement milkrandom.Source, which this package does not import.
+var (
+	_ interface{ Uint64() uint64 } = (*SplitMix64)(nil)
+	_ interface{ Uint64() uint64 } = (*SafeSplitMix64)(nil)
+)
+
 // New creates a new SplitMix64 instance seeded with the current time.
 func New() *SplitMix64 {
 	x := &SplitMix64{}
-	x.Seed(uint64(time.Now().UnixNano()))
+	x.seedClock()
 	return x
 }
 
 // NewSafe creates a new safe SplitMix64 instance seeded with the current time.
 func NewSafe() *SafeSplitMix64 {
 	x := &SafeSplitMix64{}
-	x.Seed(uint64(time.Now().UnixNano()))
+	x.seedClock()
 	return x
 }
 
+// NewSafeFrom creates a new safe SplitMix64 instance that continues from the current state of src.
+// src itself is not modified and remains unsafe for concurrent use.
+func NewSafeFrom(src *SplitMix64) *SafeSplitMix64 {
+	return &SafeSplitMix64{SplitMix64: *src}
+}
+
+// NewSnapshot creates a new SplitMix64 instance seeded with seed and returns it together with its marshaled
+// initial state, so the exact starting point can later be restored with Unmarshal.
+func NewSnapshot(seed uint64) (*SplitMix64, []byte) {
+	x := &SplitMix64{}
+	x.Seed(seed)
+	state, _ := x.Marshal() // Marshal never fails
+	return x, state
+}
+
 // State returns the current state of the random number generator.
 func (x *SplitMix64) State() uint64 {
 	return x.state
@@ -45,9 +71,24 @@ func (x *SafeSplitMix64) State() uint64 {
 	return x.SplitMix64.State()
 }
 
+// Checksum returns a 64-bit hash of the current state of the random number generator.
+// Identical states produce identical checksums, so it can be used to compare generators without exposing their state.
+func (x *SplitMix64) Checksum() uint64 {
+	h := SplitMix64{state: x.state}
+	h.state = h.Uint64()
+	return h.Uint64()
+}
+
+// Checksum returns a 64-bit hash of the current state of the random number generator, which is safe for concurrent use.
+func (x *SafeSplitMix64) Checksum() uint64 {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.SplitMix64.Checksum()
+}
+
 // Reset resets the state of the random number generator to the seed value.
 func (x *SplitMix64) Reset() {
-	x.Seed(uint64(time.Now().UnixNano()))
+	x.seedClock()
 }
 
 // Reset resets the state of the random number generator to the seed value, which is safe for concurrent use.
@@ -77,6 +118,7 @@ func (x *SplitMix64) Unmarshal(data []byte) error {
 		return errors.New("splitmix64: invalid state length")
 	}
 	x.state = binary.LittleEndian.Uint64(data)
+	x.seeded = false
 	return nil
 }
 
@@ -87,9 +129,47 @@ func (x *SafeSplitMix64) Unmarshal(data []byte) error {
 	return x.SplitMix64.Unmarshal(data)
 }
 
+// MarshalJSON returns a canonical JSON encoding of the current state of the random number generator.
+// The state is written as fixed-width zero-padded hexadecimal, so equal states always encode to identical bytes.
+func (x *SplitMix64) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`{"state":"%016x"}`, x.state)), nil
+}
+
+// MarshalJSON returns a canonical JSON encoding of the current state of the random number generator, which is safe for concurrent use.
+func (x *SafeSplitMix64) MarshalJSON() ([]byte, error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.SplitMix64.MarshalJSON()
+}
+
+// UnmarshalJSON sets the state of the random number generator to the state represented by the JSON input data.
+func (x *SplitMix64) UnmarshalJSON(data []byte) error {
+	var v struct {
+		State string `json:"state"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	state, err := parseHex64(v.State)
+	if err != nil {
+		return err
+	}
+	x.state = state
+	x.seeded = false
+	return nil
+}
+
+// UnmarshalJSON sets the state of the random number generator to the state represented by the JSON input data, which is safe for concurrent use.
+func (x *SafeSplitMix64) UnmarshalJSON(data []byte) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.SplitMix64.UnmarshalJSON(data)
+}
+
 // Seed initializes the state of the random number generator with the given seed value.
 func (x *SplitMix64) Seed(seed uint64) {
 	x.state = seed
+	x.seed, x.seeded = seed, true
 }
 
 // Seed initializes the state of the random number generator with the given seed value, which is safe for concurrent use.
@@ -99,6 +179,25 @@ func (x *SafeSplitMix64) Seed(seed uint64) {
 	x.SplitMix64.Seed(seed)
 }
 
+// SeedN initializes the state of the random number generator from multiple seed values.
+// The values are folded together with SplitMix64 mixing, so the same sequence of values always yields the same state.
+func (x *SplitMix64) SeedN(seeds ...uint64) {
+	var h SplitMix64
+	for _, s := range seeds {
+		h.state ^= s
+		h.state = h.Uint64()
+	}
+	x.state = h.state
+	x.seeded = false
+}
+
+// SeedN initializes the state of the random number generator from multiple seed values, which is safe for concurrent use.
+func (x *SafeSplitMix64) SeedN(seeds ...uint64) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.SplitMix64.SeedN(seeds...)
+}
+
 // Uint64 generates a random 64-bit unsigned integer.
 func (x *SplitMix64) Uint64() uint64 {
 	x.state += 0x9e3779b97f4a7c15
@@ -159,9 +258,10 @@ func (x *SplitMix64) Int(n int) int {
 	if n&(n-1) == 0 { // n is 2^m, use mask
 		return int(x.Uint64() & uint64(n-1))
 	}
-	max := uint64((1 << 63) - 1 - (1<<63)%uint64(n))
+	// Reject the 2^64 mod n smallest draws so that the rest split evenly into n residues.
+	threshold := -uint64(n) % uint64(n)
 	v := x.Uint64()
-	for v > max {
+	for v < threshold {
 		v = x.Uint64()
 	}
 	return int(v % uint64(n))
@@ -177,6 +277,34 @@ func (x *SafeSplitMix64) Int(n int) int {
 	return x.SplitMix64.Int(n)
 }
 
+// Uint64Range generates a random 64-bit unsigned integer in the range [min, max).
+// It panics if min >= max.
+func (x *SplitMix64) Uint64Range(min, max uint64) uint64 {
+	if min >= max {
+		panic("splitmix64: invalid argument to Uint64Range")
+	}
+	n := max - min
+	if n&(n-1) == 0 { // n is 2^m, use mask
+		return min + x.Uint64()&(n-1)
+	}
+	threshold := -n % n // 2^64 mod n
+	v := x.Uint64()
+	for v < threshold {
+		v = x.Uint64()
+	}
+	return min + v%n
+}
+
+// Uint64Range generates a random 64-bit unsigned integer in the range [min, max), which is safe for concurrent use.
+func (x *SafeSplitMix64) Uint64Range(min, max uint64) uint64 {
+	if min >= max {
+		panic("splitmix64: invalid argument to Uint64Range")
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.SplitMix64.Uint64Range(min, max)
+}
+
 // Float64 generates a random float64 in the range [0.0, 1.0).
 func (x *SplitMix64) Float64() float64 {
 	return float64(x.Uint64()>>(64-53)) / (1 << 53)
@@ -189,6 +317,38 @@ func (x *SafeSplitMix64) Float64() float64 {
 	return x.SplitMix64.Float64()
 }
 
+// Float64Closed generates a random float64 in the closed range [0.0, 1.0].
+// Unlike Float64, both 0.0 and 1.0 can be returned.
+func (x *SplitMix64) Float64Closed() float64 {
+	return float64(x.Uint64()>>(64-53)) / (1<<53 - 1)
+}
+
+// Float64Closed generates a random float64 in the closed range [0.0, 1.0], which is safe for concurrent use.
+func (x *SafeSplitMix64) Float64Closed() float64 {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.SplitMix64.Float64Closed()
+}
+
+// Float64Bits generates a random float64 in the range [0.0, 1.0) using exactly mantissaBits bits of randomness.
+// The result is a multiple of 2^-mantissaBits. It panics if mantissaBits is not in [1, 53].
+func (x *SplitMix64) Float64Bits(mantissaBits int) float64 {
+	if mantissaBits < 1 || mantissaBits > 53 {
+		panic("splitmix64: argument to Float64Bits is not in [1, 53]")
+	}
+	return float64(x.Uint64()>>(64-mantissaBits)) / float64(uint64(1)<<mantissaBits)
+}
+
+// Float64Bits generates a random float64 in the range [0.0, 1.0) using exactly mantissaBits bits of randomness, which is safe for concurrent use.
+func (x *SafeSplitMix64) Float64Bits(mantissaBits int) float64 {
+	if mantissaBits < 1 || mantissaBits > 53 {
+		panic("splitmix64: argument to Float64Bits is not in [1, 53]")
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.SplitMix64.Float64Bits(mantissaBits)
+}
+
 // Float32 generates a random float32 in the range [0.0, 1.0).
 func (x *SplitMix64) Float32() float32 {
 	return float32(x.Uint32()>>(32-24)) / (1 << 24)
@@ -200,3 +360,244 @@ func (x *SafeSplitMix64) Float32() float32 {
 	defer x.mu.Unlock()
 	return x.SplitMix64.Float32()
 }
+
+// parseHex64 parses a 16-digit hexadecimal state word as written by MarshalJSON.
+func parseHex64(s string) (uint64, error) {
+	if len(s) != 16 {
+		return 0, errors.New("splitmix64: invalid JSON state")
+	}
+	v, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return 0, errors.New("splitmix64: invalid JSON state")
+	}
+	return v, nil
+}
+
+// Split generates a random 64-bit unsigned integer and a float64 in the range [0.0, 1.0) derived from the same draw.
+// The float is built from the high 53 bits exactly as Float64 builds it.
+func (x *SplitMix64) Split() (uint64, float64) {
+	v := x.Uint64()
+	return v, float64(v>>(64-53)) / (1 << 53)
+}
+
+// Split generates a random 64-bit unsigned integer and a float64 derived from the same draw, which is safe for concurrent use.
+func (x *SafeSplitMix64) Split() (uint64, float64) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.SplitMix64.Split()
+}
+
+// FillIntN fills dst with random integers in the range [0, n).
+// For n <= 2^16 each 64-bit draw is split into four 16-bit chunks, used from the least significant end,
+// and chunks at or above the largest multiple of n are rejected. Larger n fall back to one Int call per element,
+// so for those bounds the output matches calling Int repeatedly. It panics if n <= 0.
+func (x *SplitMix64) FillIntN(dst []int, n int) {
+	if n <= 0 {
+		panic("splitmix64: argument to FillIntN is <= 0")
+	}
+	if n > 1<<16 {
+		for i := range dst {
+			dst[i] = x.Int(n)
+		}
+		return
+	}
+	limit := uint64(1<<16 - (1<<16)%n)
+	for i := 0; i < len(dst); {
+		v := x.Uint64()
+		for j := 0; j < 4 && i < len(dst); j++ {
+			c := v & 0xffff
+			v >>= 16
+			if c < limit {
+				dst[i] = int(c % uint64(n))
+				i++
+			}
+		}
+	}
+}
+
+// FillIntN fills dst with random integers in the range [0, n), holding the lock once for the whole slice, which is safe for concurrent use.
+func (x *SafeSplitMix64) FillIntN(dst []int, n int) {
+	if n <= 0 {
+		panic("splitmix64: argument to FillIntN is <= 0")
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.SplitMix64.FillIntN(dst, n)
+}
+
+// ShuffleRange shuffles the elements with indices in [i, j) using the Fisher–Yates algorithm,
+// leaving all other elements in place. swap swaps the elements with indexes a and b.
+// It panics if i < 0 or j < i.
+func (x *SplitMix64) ShuffleRange(i, j int, swap func(a, b int)) {
+	if i < 0 || j < i {
+		panic("splitmix64: invalid argument to ShuffleRange")
+	}
+	for k := j - 1; k > i; k-- {
+		swap(k, i+x.Int(k-i+1))
+	}
+}
+
+// ShuffleRange shuffles the elements with indices in [i, j), which is safe for concurrent use.
+// The lock is held for the whole shuffle, so swap must not use the generator.
+func (x *SafeSplitMix64) ShuffleRange(i, j int, swap func(a, b int)) {
+	if i < 0 || j < i {
+		panic("splitmix64: invalid argument to ShuffleRange")
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.SplitMix64.ShuffleRange(i, j, swap)
+}
+
+// Stir deterministically reseeds the random number generator from its own state by passing every
+// state word through SeedN. The resulting state is a fixed function of the current one, so replays
+// stay reproducible, but the generator continues on a different stream than it would have without Stir.
+func (x *SplitMix64) Stir() {
+	x.SeedN(x.state)
+}
+
+// Stir deterministically reseeds the random number generator from its own state, which is safe for concurrent use.
+func (x *SafeSplitMix64) Stir() {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.SplitMix64.Stir()
+}
+
+// FullInt64 generates a random 64-bit signed integer over the full range [math.MinInt64, math.MaxInt64].
+// Unlike Int64, which is always non-negative, it reinterprets all 64 output bits in two's complement,
+// so outputs with the high bit set are negative and int64(uint64(v)) round-trips exactly.
+func (x *SplitMix64) FullInt64() int64 {
+	return int64(x.Uint64())
+}
+
+// FullInt64 generates a random 64-bit signed integer over the full range, which is safe for concurrent use.
+func (x *SafeSplitMix64) FullInt64() int64 {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.SplitMix64.FullInt64()
+}
+
+// Derive returns a new, independent random number generator seeded from the seed of this one combined with name.
+// The same seed and name always yield the same sub-generator, however many values this generator has produced
+// since Seed, while different names yield unrelated streams. If CurrentSeed reports no seed, the Checksum of the
+// current state is used in its place. The state of this generator is not advanced.
+func (x *SplitMix64) Derive(name string) *SplitMix64 {
+	base, ok := x.CurrentSeed()
+	if !ok {
+		base = x.Checksum()
+	}
+	d := &SplitMix64{}
+	d.SeedN(append([]uint64{base}, stringWords(name)...)...)
+	return d
+}
+
+// Derive returns a new, independent safe random number generator seeded from the seed of this one
+// combined with name, which is safe for concurrent use.
+func (x *SafeSplitMix64) Derive(name string) *SafeSplitMix64 {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return &SafeSplitMix64{SplitMix64: *x.SplitMix64.Derive(name)}
+}
+
+// stringWords splits s into little-endian 64-bit words, zero-padding the last one, followed by its length.
+func stringWords(s string) []uint64 {
+	words := make([]uint64, 0, len(s)/8+2)
+	for i := 0; i < len(s); i += 8 {
+		var w uint64
+		for j := i; j < len(s) && j < i+8; j++ {
+			w |= uint64(s[j]) << (8 * (j - i))
+		}
+		words = append(words, w)
+	}
+	return append(words, uint64(len(s)))
+}
+
+// PermInto fills dst with a random permutation of the integers [0, len(dst)), overwriting its contents.
+// The permutation is built with the inside-out Fisher–Yates algorithm, one Int call per element after the first,
+// so the buffer can be reused across calls without allocating.
+func (x *SplitMix64) PermInto(dst []int) {
+	if len(dst) == 0 {
+		return
+	}
+	dst[0] = 0
+	for i := 1; i < len(dst); i++ {
+		j := x.Int(i + 1)
+		dst[i] = dst[j]
+		dst[j] = i
+	}
+}
+
+// PermInto fills dst with a random permutation of the integers [0, len(dst)), which is safe for concurrent use.
+func (x *SafeSplitMix64) PermInto(dst []int) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.SplitMix64.PermInto(dst)
+}
+
+// CurrentSeed returns the seed most recently passed to Seed, so a result can be reproduced by seeding with it again.
+// ok is false if the generator was instead seeded from the clock or with SeedN, or had its state loaded with Unmarshal or UnmarshalJSON.
+func (x *SplitMix64) CurrentSeed() (seed uint64, ok bool) {
+	if !x.seeded {
+		return 0, false
+	}
+	return x.seed, true
+}
+
+// CurrentSeed returns the seed most recently passed to Seed, which is safe for concurrent use.
+func (x *SafeSplitMix64) CurrentSeed() (seed uint64, ok bool) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.SplitMix64.CurrentSeed()
+}
+
+// seedClock seeds the random number generator from the current time. The clock value is not reported by CurrentSeed.
+func (x *SplitMix64) seedClock() {
+	x.Seed(uint64(time.Now().UnixNano()))
+	x.seeded = false
+}
+
+// IntExcept generates a random integer in the range [0, n) that is never exclude, with all other values equally likely.
+// It draws from [0, n-1) and shifts values at or above exclude up by one. It panics if n <= 1 or exclude is not in [0, n).
+func (x *SplitMix64) IntExcept(n, exclude int) int {
+	if n <= 1 || exclude < 0 || exclude >= n {
+		panic("splitmix64: invalid argument to IntExcept")
+	}
+	v := x.Int(n - 1)
+	if v >= exclude {
+		v++
+	}
+	return v
+}
+
+// IntExcept generates a random integer in the range [0, n) that is never exclude, which is safe for concurrent use.
+func (x *SafeSplitMix64) IntExcept(n, exclude int) int {
+	if n <= 1 || exclude < 0 || exclude >= n {
+		panic("splitmix64: invalid argument to IntExcept")
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.SplitMix64.IntExcept(n, exclude)
+}
+
+// Shuffle randomizes the order of n elements with the backward Fisher–Yates algorithm. swap swaps the elements with indexes a and b.
+// The draw sequence is fixed: for i from n-1 down to 1 it calls swap(i, j) with j = Int(i + 1), so any implementation making the
+// same Int calls on the same state reproduces the result. The order of swaps matches math/rand.Shuffle, though j is drawn by this
+// generator's Int rather than by math/rand. It is equivalent to ShuffleRange(0, n, swap). It panics if n < 0.
+func (x *SplitMix64) Shuffle(n int, swap func(a, b int)) {
+	if n < 0 {
+		panic("splitmix64: argument to Shuffle is < 0")
+	}
+	for i := n - 1; i > 0; i-- {
+		swap(i, x.Int(i+1))
+	}
+}
+
+// Shuffle randomizes the order of n elements with the backward Fisher–Yates algorithm, which is safe for concurrent use.
+// The lock is held for the whole shuffle, so swap must not use the generator.
+func (x *SafeSplitMix64) Shuffle(n int, swap func(a, b int)) {
+	if n < 0 {
+		panic("splitmix64: argument to Shuffle is < 0")
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.SplitMix64.Shuffle(n, swap)
+}
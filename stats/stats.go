@@ -0,0 +1,88 @@
+// Package stats provides helpers for inspecting the output of random number generators and distributions.
+package stats
+
+import (
+	"math"
+
+	"github.com/MilkLua/milkrandom"
+)
+
+// Histogram draws samples values from sampler using src and counts them into bins
+// equal-width bins covering [min, max). Values outside that range are not counted.
+func Histogram(src milkrandom.Source, sampler func(milkrandom.Source) float64, bins int, min, max float64, samples int) []int {
+	if bins <= 0 {
+		panic("stats: argument bins to Histogram is <= 0")
+	}
+	if !(min < max) {
+		panic("stats: invalid range for Histogram")
+	}
+	counts := make([]int, bins)
+	width := (max - min) / float64(bins)
+	for i := 0; i < samples; i++ {
+		v := sampler(src)
+		if !(v >= min && v < max) {
+			continue
+		}
+		b := int((v - min) / width)
+		if b >= bins { // guard against rounding at the upper edge
+			b = bins - 1
+		}
+		counts[b]++
+	}
+	return counts
+}
+
+// RunningStats accumulates the mean and variance of a stream of values using Welford's
+// algorithm, which stays numerically stable over very long streams. The zero value is
+// ready to use.
+type RunningStats struct {
+	n    int64
+	mean float64
+	m2   float64
+}
+
+// Add adds a value to the statistics.
+func (s *RunningStats) Add(x float64) {
+	s.n++
+	delta := x - s.mean
+	s.mean += delta / float64(s.n)
+	s.m2 += delta * (x - s.mean)
+}
+
+// Count returns the number of values added.
+func (s *RunningStats) Count() int64 {
+	return s.n
+}
+
+// Mean returns the mean of the values added, or 0 if none were added.
+func (s *RunningStats) Mean() float64 {
+	return s.mean
+}
+
+// Variance returns the sample variance (with n-1 in the denominator) of the values added,
+// or 0 if fewer than two values were added.
+func (s *RunningStats) Variance() float64 {
+	if s.n < 2 {
+		return 0
+	}
+	return s.m2 / float64(s.n-1)
+}
+
+// StdDev returns the sample standard deviation of the values added.
+func (s *RunningStats) StdDev() float64 {
+	return math.Sqrt(s.Variance())
+}
+
+// DrawsPerSample runs sampler trials times on src and returns the average number of values
+// drawn from src per sample. It can be used to compare the cost of different samplers,
+// such as a rejection sampler against a direct transform. It panics if trials <= 0.
+func DrawsPerSample(src milkrandom.Source, sampler func(milkrandom.Source) float64, trials int) float64 {
+	if trials <= 0 {
+		panic("stats: argument trials to DrawsPerSample is <= 0")
+	}
+	c := milkrandom.NewCountingSource(src)
+	for i := 0; i < trials; i++ {
+		sampler(c)
+	}
+	return float64(c.Count()) / float64(trials)
+}
@@ -0,0 +1,115 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/MilkLua/milkrandom"
+	"github.com/MilkLua/milkrandom/splitmix64"
+)
+
+func newSource(seed uint64) milkrandom.Source {
+	s := &splitmix64.SplitMix64{}
+	s.Seed(seed)
+	return s
+}
+
+func TestHistogramUniform(t *testing.T) {
+	uniform := func(src milkrandom.Source) float64 { return float64(src.Uint64()>>11) / (1 << 53) }
+	const samples = 100000
+	counts := Histogram(newSource(1), uniform, 10, 0, 1, samples)
+	total := 0
+	for i, c := range counts {
+		total += c
+		if math.Abs(float64(c)-samples/10) > 0.05*samples/10 {
+			t.Errorf("bin %d holds %d values, want about %d", i, c, samples/10)
+		}
+	}
+	if total != samples {
+		t.Errorf("histogram counted %d values, want %d", total, samples)
+	}
+}
+
+func TestHistogramNormal(t *testing.T) {
+	const samples = 100000
+	counts := Histogram(newSource(1), milkrandom.NormFloat64, 8, -4, 4, samples)
+	// Bins have width 1; the expected share of each is the normal probability of its interval.
+	for i, c := range counts {
+		lo, hi := float64(i-4), float64(i-3)
+		want := samples * (math.Erf(hi/math.Sqrt2) - math.Erf(lo/math.Sqrt2)) / 2
+		if math.Abs(float64(c)-want) > 0.05*want+20 {
+			t.Errorf("bin [%v, %v) holds %d values, want about %.0f", lo, hi, c, want)
+		}
+	}
+	if counts[3] < 2*counts[2] || counts[4] < 2*counts[5] {
+		t.Errorf("histogram %v is not bell shaped", counts)
+	}
+}
+
+func TestRunningStatsKnownData(t *testing.T) {
+	var s RunningStats
+	for _, x := range []float64{2, 4, 4, 4, 5, 5, 7, 9} {
+		s.Add(x)
+	}
+	if s.Count() != 8 {
+		t.Errorf("Count() = %d, want 8", s.Count())
+	}
+	if s.Mean() != 5 {
+		t.Errorf("Mean() = %v, want 5", s.Mean())
+	}
+	if want := 32.0 / 7; math.Abs(s.Variance()-want) > 1e-12 {
+		t.Errorf("Variance() = %v, want %v", s.Variance(), want)
+	}
+	if want := math.Sqrt(32.0 / 7); math.Abs(s.StdDev()-want) > 1e-12 {
+		t.Errorf("StdDev() = %v, want %v", s.StdDev(), want)
+	}
+}
+
+func TestRunningStatsLargeOffset(t *testing.T) {
+	// A naive sum of squares loses every significant digit of the variance here.
+	var s RunningStats
+	for i := 0; i < 1000000; i++ {
+		for _, x := range []float64{4, 7, 13, 16} {
+			s.Add(1e9 + x)
+		}
+	}
+	if math.Abs(s.Mean()-(1e9+10)) > 1e-6 {
+		t.Errorf("Mean() = %v, want %v", s.Mean(), 1e9+10)
+	}
+	// Population variance of {4, 7, 13, 16} is 22.5; with n-1 the difference is negligible.
+	if math.Abs(s.Variance()-22.5) > 1e-4 {
+		t.Errorf("Variance() = %v, want about 22.5", s.Variance())
+	}
+}
+
+func TestRunningStatsEmpty(t *testing.T) {
+	var s RunningStats
+	if s.Mean() != 0 || s.Variance() != 0 {
+		t.Errorf("zero value: Mean() = %v, Variance() = %v, want 0, 0", s.Mean(), s.Variance())
+	}
+	s.Add(3)
+	if s.Variance() != 0 {
+		t.Errorf("Variance() of one value = %v, want 0", s.Variance())
+	}
+}
+
+func TestDrawsPerSample(t *testing.T) {
+	uniform := func(src milkrandom.Source) float64 { return float64(src.Uint64()>>11) / (1 << 53) }
+	if got := DrawsPerSample(newSource(1), uniform, 1000); got != 1 {
+		t.Errorf("DrawsPerSample(uniform) = %v, want 1", got)
+	}
+
+	// Rejection sampling a point in the unit disk from the enclosing square accepts with
+	// probability pi/4 and uses two draws per attempt, so 8/pi draws per sample on average.
+	disk := func(src milkrandom.Source) float64 {
+		for {
+			x, y := 2*uniform(src)-1, 2*uniform(src)-1
+			if x*x+y*y < 1 {
+				return x
+			}
+		}
+	}
+	if got, want := DrawsPerSample(newSource(1), disk, 100000), 8/math.Pi; math.Abs(got-want) > 0.03 {
+		t.Errorf("DrawsPerSample(disk) = %v, want about %v", got, want)
+	}
+}
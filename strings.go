@@ -0,0 +1,108 @@
+package milkrandom
+
+import (
+	"strings"
+	"unicode"
+)
+
+const base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// Base62 generates a random string of the given length over the alphabet [0-9A-Za-z].
+// Each character is drawn from 6-bit chunks of the source output, rejecting chunks
+// that fall outside the alphabet, so every character is equally likely.
+func Base62(src Source, length int) string {
+	if length < 0 {
+		panic("milkrandom: argument to Base62 is < 0")
+	}
+	buf := make([]byte, length)
+	var bits uint64
+	avail := 0
+	for i := 0; i < length; {
+		if avail == 0 {
+			bits = src.Uint64()
+			avail = 64 / 6
+		}
+		c := bits & 63
+		bits >>= 6
+		avail--
+		if c < uint64(len(base62Alphabet)) {
+			buf[i] = base62Alphabet[c]
+			i++
+		}
+	}
+	return string(buf)
+}
+
+// UTF8String generates a random valid UTF-8 string of runeCount code points. Each code point is
+// drawn uniformly from all Unicode scalar values except the noncharacters U+FDD0 to U+FDEF and
+// U+nFFFE and U+nFFFF in every plane; surrogates are never produced. It panics if runeCount < 0.
+func UTF8String(src Source, runeCount int) string {
+	if runeCount < 0 {
+		panic("milkrandom: argument to UTF8String is < 0")
+	}
+	var sb strings.Builder
+	sb.Grow(runeCount)
+	for i := 0; i < runeCount; {
+		r := rune(intn(src, unicode.MaxRune+1))
+		if (r >= 0xd800 && r <= 0xdfff) || (r >= 0xfdd0 && r <= 0xfdef) || r&0xfffe == 0xfffe {
+			continue
+		}
+		sb.WriteRune(r)
+		i++
+	}
+	return sb.String()
+}
+
+// Passphrase returns words words picked uniformly with replacement from wordlist and joined by
+// separator. It panics if words < 0, or if words > 0 and wordlist is empty.
+func Passphrase(src Source, words int, wordlist []string, separator string) string {
+	if words < 0 {
+		panic("milkrandom: argument words to Passphrase is < 0")
+	}
+	if words > 0 && len(wordlist) == 0 {
+		panic("milkrandom: empty wordlist passed to Passphrase")
+	}
+	picked := make([]string, words)
+	for i := range picked {
+		picked[i] = wordlist[intn(src, len(wordlist))]
+	}
+	return strings.Join(picked, separator)
+}
+
+// Password returns a random password of length characters that contains at least one character
+// from each of charsets. One character is drawn from every charset, the rest are drawn uniformly
+// from the union of all charsets, and the result is shuffled so the guaranteed characters can
+// appear anywhere. Charsets are read as UTF-8, so they may contain multi-byte characters.
+// It panics if no charsets are given, if any charset is empty, or if length < len(charsets).
+func Password(src Source, length int, charsets ...string) string {
+	if len(charsets) == 0 {
+		panic("milkrandom: no charsets passed to Password")
+	}
+	if length < len(charsets) {
+		panic("milkrandom: argument length to Password is less than the number of charsets")
+	}
+	var union []rune
+	seen := make(map[rune]bool)
+	pw := make([]rune, 0, length)
+	for _, cs := range charsets {
+		set := []rune(cs)
+		if len(set) == 0 {
+			panic("milkrandom: empty charset passed to Password")
+		}
+		pw = append(pw, set[intn(src, len(set))])
+		for _, r := range set {
+			if !seen[r] {
+				seen[r] = true
+				union = append(union, r)
+			}
+		}
+	}
+	for len(pw) < length {
+		pw = append(pw, union[intn(src, len(union))])
+	}
+	for i := len(pw) - 1; i > 0; i-- {
+		j := intn(src, i+1)
+		pw[i], pw[j] = pw[j], pw[i]
+	}
+	return string(pw)
+}
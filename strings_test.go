@@ -0,0 +1,140 @@
+package milkrandom
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestBase62(t *testing.T) {
+	for _, n := range []int{0, 1, 11, 64} {
+		s := Base62(newTestSource(1), n)
+		if len(s) != n {
+			t.Errorf("len(Base62(%d)) = %d", n, len(s))
+		}
+		if again := Base62(newTestSource(1), n); again != s {
+			t.Errorf("Base62(%d) with the same seed returned %q then %q", n, s, again)
+		}
+	}
+	const draws = 62 * 2000
+	s := Base62(newTestSource(2), draws)
+	counts := make(map[rune]int)
+	for _, c := range s {
+		if !strings.ContainsRune(base62Alphabet, c) {
+			t.Fatalf("Base62 produced %q, outside the alphabet", c)
+		}
+		counts[c]++
+	}
+	if len(counts) != 62 {
+		t.Errorf("Base62 used %d distinct characters, want 62", len(counts))
+	}
+	// Chi-squared with 61 degrees of freedom; 100 is beyond the 99.9th percentile.
+	chi2 := 0.0
+	for _, c := range base62Alphabet {
+		d := float64(counts[c]) - draws/62
+		chi2 += d * d / (draws / 62)
+	}
+	if chi2 > 100 {
+		t.Errorf("character counts are not uniform: chi-squared = %v", chi2)
+	}
+}
+
+func TestUTF8String(t *testing.T) {
+	src := newTestSource(1)
+	planes := make(map[rune]bool)
+	for _, n := range []int{0, 1, 10, 1000} {
+		s := UTF8String(src, n)
+		if !utf8.ValidString(s) {
+			t.Fatalf("UTF8String(%d) is not valid UTF-8", n)
+		}
+		if got := utf8.RuneCountInString(s); got != n {
+			t.Fatalf("UTF8String(%d) has %d runes", n, got)
+		}
+		for _, r := range s {
+			if r >= 0xd800 && r <= 0xdfff {
+				t.Fatalf("UTF8String(%d) contains the surrogate %U", n, r)
+			}
+			if (r >= 0xfdd0 && r <= 0xfdef) || r&0xfffe == 0xfffe {
+				t.Fatalf("UTF8String(%d) contains the noncharacter %U", n, r)
+			}
+			planes[r>>16] = true
+		}
+	}
+	// 1011 code points drawn uniformly from 17 planes leave none of them out.
+	if len(planes) != 17 {
+		t.Errorf("UTF8String produced code points from %d planes, want all 17", len(planes))
+	}
+	if UTF8String(newTestSource(7), 50) != UTF8String(newTestSource(7), 50) {
+		t.Error("UTF8String differs between sources with the same seed")
+	}
+}
+
+func TestPassword(t *testing.T) {
+	charsets := []string{"abcdefghijklmnopqrstuvwxyz", "ABCDEFGHIJKLMNOPQRSTUVWXYZ", "0123456789", "!@#$%", "äöü"}
+	all := strings.Join(charsets, "")
+	src := newTestSource(1)
+	positions := make(map[int]bool)
+	for i := 0; i < 2000; i++ {
+		// At the minimum length every character is a guaranteed one; longer passwords add filler.
+		length := len(charsets) + i%4
+		pw := Password(src, length, charsets...)
+		if n := utf8.RuneCountInString(pw); n != length {
+			t.Fatalf("Password(%d) = %q has %d characters", length, pw, n)
+		}
+		for _, cs := range charsets {
+			if !strings.ContainsAny(pw, cs) {
+				t.Fatalf("Password(%d) = %q has no character from %q", length, pw, cs)
+			}
+		}
+		for j, r := range []rune(pw) {
+			if !strings.ContainsRune(all, r) {
+				t.Fatalf("Password(%d) = %q contains %q, which is in no charset", length, pw, r)
+			}
+			if strings.ContainsRune(charsets[3], r) {
+				positions[j] = true
+			}
+		}
+	}
+	// The shuffle moves the guaranteed symbol away from the position it was drawn in.
+	if len(positions) < len(charsets) {
+		t.Errorf("symbols appeared only at positions %v", positions)
+	}
+	if Password(newTestSource(7), 16, charsets...) != Password(newTestSource(7), 16, charsets...) {
+		t.Error("Password differs between sources with the same seed")
+	}
+
+	for _, c := range []struct {
+		length   int
+		charsets []string
+	}{{4, nil}, {1, []string{"a", "b"}}, {4, []string{"a", ""}}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Password(%d, %q) did not panic", c.length, c.charsets)
+				}
+			}()
+			Password(src, c.length, c.charsets...)
+		}()
+	}
+}
+
+func TestPassphrase(t *testing.T) {
+	words := []string{"correct", "horse", "battery", "staple"}
+	p := Passphrase(newTestSource(7), 6, words, "-")
+	if p != Passphrase(newTestSource(7), 6, words, "-") {
+		t.Error("Passphrase differs between sources with the same seed")
+	}
+	parts := strings.Split(p, "-")
+	if len(parts) != 6 {
+		t.Fatalf("Passphrase(6) = %q has %d words", p, len(parts))
+	}
+	inList := map[string]bool{"correct": true, "horse": true, "battery": true, "staple": true}
+	for _, w := range parts {
+		if !inList[w] {
+			t.Errorf("Passphrase(6) = %q contains %q, which is not in the wordlist", p, w)
+		}
+	}
+	if p := Passphrase(newTestSource(1), 0, nil, " "); p != "" {
+		t.Errorf("Passphrase(0) = %q, want empty", p)
+	}
+}
@@ -0,0 +1,268 @@
+"""Reference implementations used to generate the golden files in testdata/golden.
+
+The generators are written with Python integers, independently of the Go code, and
+are checked against published output before any file is written:
+
+  - PCG32: pcg32-demo from the PCG C reference (initstate 42, initseq 54).
+  - SplitMix64: the reference sequence for seed 1234567.
+  - SeedSequence: O'Neill's seed_seq_fe128 reference data, as used by numpy's tests.
+
+The PCG64DXSM vectors follow numpy's seeding code for numpy.random.PCG64DXSM(seed):
+SeedSequence expands the seed into four 64-bit words, which pcg_setseq_128_srandom_r
+turns into the initial state in the same way as SeedState. They are not numpy's own
+output; TestNumpyPCG64DXSMTestSet compares against that when numpy's test set files
+are copied into testdata/numpy.
+
+Run from the repository root:
+
+    python3 testdata/reference.py
+"""
+
+M32 = (1 << 32) - 1
+M64 = (1 << 64) - 1
+M128 = (1 << 128) - 1
+
+
+def rotr32(x, k):
+    return ((x >> k) | (x << ((-k) & 31))) & M32
+
+
+def rotr64(x, k):
+    return ((x >> k) | (x << ((-k) & 63))) & M64
+
+
+def rotl64(x, k):
+    return rotr64(x, (-k) & 63)
+
+
+def pcg32_srandom(initstate, initseq):
+    st = {'state': 0, 'inc': ((initseq << 1) | 1) & M64}
+    pcg32_next(st)
+    st['state'] = (st['state'] + initstate) & M64
+    pcg32_next(st)
+    return st
+
+
+def pcg32_seed(seed):
+    return pcg32_srandom(seed, seed)
+
+
+def pcg32_next(st):
+    old = st['state']
+    st['state'] = (old * 6364136223846793005 + st['inc']) & M64
+    return rotr32((((old >> 18) ^ old) >> 27) & M32, old >> 59)
+
+
+PCG64_MULT = (0x14057b7ef767814f << 64) | 0x5851f42d4c957f2d
+
+
+def pcg64_seed(seed):
+    st = {'state': 0, 'inc': seed | 1}
+    pcg64_next(st)
+    st['state'] = (st['state'] + st['inc']) & M128
+    pcg64_next(st)
+    return st
+
+
+def pcg64_next(st):
+    old = st['state']
+    st['state'] = (old * PCG64_MULT + st['inc']) & M128
+    return rotr64(rotr64((old >> 64) ^ (old & M64), 29), old >> 122)
+
+
+CHEAP_MULTIPLIER = 0xda942042e4dd58b5
+# PCG_DEFAULT_MULTIPLIER_128, which pcg_setseq_128_srandom_r uses while seeding.
+DEFAULT_MULTIPLIER_128 = (2549297995355413924 << 64) | 4865540595714422341
+
+
+def pcg64dxsm_seedstate(state_hi, state_lo, seq_hi, seq_lo):
+    st = {'state': 0, 'inc': ((((seq_hi << 64) | seq_lo) << 1) | 1) & M128}
+    pcg_setseq_128_step(st)
+    st['state'] = (st['state'] + ((state_hi << 64) | state_lo)) & M128
+    pcg_setseq_128_step(st)
+    return st
+
+
+def pcg_setseq_128_step(st):
+    st['state'] = (st['state'] * DEFAULT_MULTIPLIER_128 + st['inc']) & M128
+
+
+def pcg64dxsm_step(st):
+    st['state'] = (st['state'] * CHEAP_MULTIPLIER + st['inc']) & M128
+
+
+def pcg64dxsm_next(st):
+    hi = st['state'] >> 64
+    lo = (st['state'] & M64) | 1
+    hi ^= hi >> 32
+    hi = (hi * CHEAP_MULTIPLIER) & M64
+    hi ^= hi >> 48
+    hi = (hi * lo) & M64
+    pcg64dxsm_step(st)
+    return hi
+
+
+# numpy.random.SeedSequence with the default pool size of four 32-bit words.
+SS_INIT_A, SS_MULT_A = 0x43b0d7e5, 0x931e8875
+SS_INIT_B, SS_MULT_B = 0x8b51f9dd, 0x58f38ded
+SS_MIX_MULT_L, SS_MIX_MULT_R = 0xca01f9dd, 0x4973f715
+SS_POOL_SIZE = 4
+
+
+def seedseq_pool(entropy):
+    hash_const = [SS_INIT_A]
+
+    def hashmix(value):
+        value ^= hash_const[0]
+        hash_const[0] = (hash_const[0] * SS_MULT_A) & M32
+        value = (value * hash_const[0]) & M32
+        return value ^ (value >> 16)
+
+    def mix(x, y):
+        result = (SS_MIX_MULT_L * x - SS_MIX_MULT_R * y) & M32
+        return result ^ (result >> 16)
+
+    pool = [hashmix(entropy[i] if i < len(entropy) else 0) for i in range(SS_POOL_SIZE)]
+    for src in range(SS_POOL_SIZE):
+        for dst in range(SS_POOL_SIZE):
+            if src != dst:
+                pool[dst] = mix(pool[dst], hashmix(pool[src]))
+    for src in range(SS_POOL_SIZE, len(entropy)):
+        for dst in range(SS_POOL_SIZE):
+            pool[dst] = mix(pool[dst], hashmix(entropy[src]))
+    return pool
+
+
+def seedseq_generate_state32(pool, n):
+    hash_const = SS_INIT_B
+    out = []
+    for i in range(n):
+        value = pool[i % len(pool)] ^ hash_const
+        hash_const = (hash_const * SS_MULT_B) & M32
+        value = (value * hash_const) & M32
+        out.append(value ^ (value >> 16))
+    return out
+
+
+def seedseq_generate_state64(seed, n):
+    words = []
+    while True:
+        words.append(seed & M32)
+        seed >>= 32
+        if seed == 0:
+            break
+    w = seedseq_generate_state32(seedseq_pool(words), 2 * n)
+    return [w[2 * i] | (w[2 * i + 1] << 32) for i in range(n)]
+
+
+def splitmix64_next(st):
+    st[0] = (st[0] + 0x9e3779b97f4a7c15) & M64
+    z = st[0]
+    z = ((z ^ (z >> 30)) * 0xbf58476d1ce4e5b9) & M64
+    z = ((z ^ (z >> 27)) * 0x94d049bb133111eb) & M64
+    return z ^ (z >> 31)
+
+
+def pcg64dxsm_seed(seed):
+    s = [seed]
+    return pcg64dxsm_seedstate(*[splitmix64_next(s) for _ in range(4)])
+
+
+def xoshiro_seed(seed):
+    s = [seed]
+    return [splitmix64_next(s) for _ in range(4)]
+
+
+def xoshiro_next(s):
+    result = (rotl64((s[1] * 5) & M64, 7) * 9) & M64
+    t = (s[1] << 17) & M64
+    s[2] ^= s[0]
+    s[3] ^= s[1]
+    s[1] ^= s[2]
+    s[0] ^= s[3]
+    s[2] ^= t
+    s[3] = rotl64(s[3], 45)
+    return result
+
+
+SEEDS = [42, 1234567, 0xdeadbeef]
+COUNT = 100
+
+# 0xdeadbeaf is the seed of numpy's own PCG64DXSM test set.
+SEEDSEQUENCE_SEEDS = [0xdeadbeaf, 12345]
+SEEDSEQUENCE_COUNT = 20
+
+GENERATORS = {
+    'pcg32': ('Seed(seed), then Next', pcg32_seed, pcg32_next),
+    'pcg64': ('Seed(seed), then Next', pcg64_seed, pcg64_next),
+    'pcg64dxsm': ('Seed(seed), then Next', pcg64dxsm_seed, pcg64dxsm_next),
+    'splitmix64': ('Seed(seed), then Uint64', lambda seed: [seed], splitmix64_next),
+    'xoshiro256starstar': ('Seed(seed), then Uint64', xoshiro_seed, xoshiro_next),
+}
+
+
+# TestStreamStability: every generator seeded with 1 and read through Uint64.
+STABILITY = {
+    'pcg32': (pcg32_seed, lambda st: (pcg32_next(st) << 32) | pcg32_next(st)),
+    'pcg64': (pcg64_seed, pcg64_next),
+    'pcg64dxsm': (pcg64dxsm_seed, pcg64dxsm_next),
+    'splitmix64': (lambda seed: [seed], splitmix64_next),
+    'xoshiro256starstar': (xoshiro_seed, xoshiro_next),
+}
+
+
+def check_published():
+    st = pcg32_srandom(42, 54)
+    got = [pcg32_next(st) for _ in range(6)]
+    assert got == [0xa15c02b7, 0x7b47f409, 0xba1d3330, 0x83d2f293, 0xbfa4784b, 0xcbed606e], got
+    s = [1234567]
+    got = [splitmix64_next(s) for _ in range(5)]
+    assert got == [6457827717110365317, 3203168211198807973, 9817491932198370423,
+                   4593380528125082431, 16408922859458223821], got
+    got = seedseq_generate_state32(seedseq_pool([3735928559, 195939070, 229505742, 305419896]), 4)
+    assert got == [3914649087, 576849849, 3593928901, 2229911004], got
+
+
+def write_pcg64dxsm_seedsequence():
+    with open('testdata/golden/pcg64dxsm-seedsequence.txt', 'w') as f:
+        f.write('# Generated by testdata/reference.py; do not edit.\n')
+        f.write('# SeedSequence(seed).generate_state(4) as the SeedState words, then %d outputs.\n' % SEEDSEQUENCE_COUNT)
+        for seed in SEEDSEQUENCE_SEEDS:
+            words = seedseq_generate_state64(seed, 4)
+            st = pcg64dxsm_seedstate(*words)
+            f.write('seed %#x\n' % seed)
+            f.write('state %s\n' % ' '.join('%#x' % w for w in words))
+            for _ in range(SEEDSEQUENCE_COUNT):
+                f.write('%#x\n' % pcg64dxsm_next(st))
+
+
+def write_golden(name, doc, seed_fn, next_fn):
+    with open('testdata/golden/%s.txt' % name, 'w') as f:
+        f.write('# Generated by testdata/reference.py; do not edit.\n')
+        f.write('# %s: %s.\n' % (name, doc))
+        for seed in SEEDS:
+            st = seed_fn(seed)
+            f.write('seed %#x\n' % seed)
+            for _ in range(COUNT):
+                f.write('%#x\n' % next_fn(st))
+
+
+def write_stability():
+    with open('testdata/golden/stability.txt', 'w') as f:
+        f.write('# Generated by testdata/reference.py; do not edit.\n')
+        f.write('# Seed(1), then Uint64, for every generator. Changing any of these values\n')
+        f.write('# changes a seeded stream and is a breaking change.\n')
+        for name, (seed_fn, next_fn) in STABILITY.items():
+            st = seed_fn(1)
+            f.write('generator %s\n' % name)
+            f.write('seed 0x1\n')
+            for _ in range(COUNT):
+                f.write('%#x\n' % next_fn(st))
+
+
+if __name__ == '__main__':
+    check_published()
+    for name, (doc, seed_fn, next_fn) in GENERATORS.items():
+        write_golden(name, doc, seed_fn, next_fn)
+    write_pcg64dxsm_seedsequence()
+    write_stability()
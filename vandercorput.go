@@ -0,0 +1,50 @@
+package milkrandom
+
+// VDC generates the van der Corput low-discrepancy sequence in a given base: the n-th value is n
+// written in that base with its digits mirrored about the radix point, so base 2 yields 1/2, 1/4,
+// 3/4, 1/8, 5/8, and so on. The points fill [0, 1) more evenly than uniform random draws, which
+// speeds up the convergence of quasi-Monte Carlo integration. A VDC is deterministic and does not
+// use a Source.
+type VDC struct {
+	base  uint64
+	index uint64
+}
+
+// NewVanDerCorput creates a new VDC in the given base, starting at index 1 so the first value is
+// 1/base. It panics if base < 2.
+func NewVanDerCorput(base int) *VDC {
+	if base < 2 {
+		panic("milkrandom: argument to NewVanDerCorput is < 2")
+	}
+	return &VDC{base: uint64(base), index: 1}
+}
+
+// Next returns the value at the current index and advances the index by one.
+func (v *VDC) Next() float64 {
+	x := radicalInverse(v.index, v.base)
+	v.index++
+	return x
+}
+
+// Skip advances the index by n without generating values. Parallel workers can take disjoint
+// segments of one sequence by skipping to different offsets.
+func (v *VDC) Skip(n uint64) {
+	v.index += n
+}
+
+// Index returns the index of the value the next call to Next returns.
+func (v *VDC) Index() uint64 {
+	return v.index
+}
+
+// radicalInverse mirrors the base-b digits of n about the radix point.
+func radicalInverse(n, b uint64) float64 {
+	r := 0.0
+	f := 1 / float64(b)
+	for n > 0 {
+		r += f * float64(n%b)
+		n /= b
+		f /= float64(b)
+	}
+	return r
+}
@@ -0,0 +1,80 @@
+package milkrandom
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+func TestVanDerCorputKnownValues(t *testing.T) {
+	for base, want := range map[int][]float64{
+		2: {1.0 / 2, 1.0 / 4, 3.0 / 4, 1.0 / 8, 5.0 / 8, 3.0 / 8, 7.0 / 8, 1.0 / 16},
+		3: {1.0 / 3, 2.0 / 3, 1.0 / 9, 4.0 / 9, 7.0 / 9, 2.0 / 9, 5.0 / 9, 8.0 / 9},
+	} {
+		v := NewVanDerCorput(base)
+		for i, w := range want {
+			if got := v.Next(); math.Abs(got-w) > 1e-15 {
+				t.Errorf("base %d: value %d = %v, want %v", base, i+1, got, w)
+			}
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("NewVanDerCorput(1) did not panic")
+		}
+	}()
+	NewVanDerCorput(1)
+}
+
+func TestVanDerCorputSkip(t *testing.T) {
+	whole := NewVanDerCorput(5)
+	var seq []float64
+	for i := 0; i < 100; i++ {
+		seq = append(seq, whole.Next())
+	}
+	// Two workers taking the halves [0, 50) and [50, 100) of the sequence.
+	for _, offset := range []int{0, 50} {
+		w := NewVanDerCorput(5)
+		w.Skip(uint64(offset))
+		if w.Index() != uint64(offset+1) {
+			t.Errorf("Index() after Skip(%d) = %d, want %d", offset, w.Index(), offset+1)
+		}
+		for i := offset; i < offset+50; i++ {
+			if got := w.Next(); got != seq[i] {
+				t.Fatalf("worker at offset %d: value %d = %v, want %v", offset, i, got, seq[i])
+			}
+		}
+	}
+}
+
+// starDiscrepancy returns the star discrepancy of points in [0, 1).
+func starDiscrepancy(points []float64) float64 {
+	xs := append([]float64(nil), points...)
+	sort.Float64s(xs)
+	n := float64(len(xs))
+	d := 0.0
+	for i, x := range xs {
+		d = math.Max(d, math.Max(float64(i+1)/n-x, x-float64(i)/n))
+	}
+	return d
+}
+
+func TestVanDerCorputDiscrepancy(t *testing.T) {
+	const n = 4096
+	v := NewVanDerCorput(2)
+	src := newTestSource(1)
+	qmc, mc := make([]float64, n), make([]float64, n)
+	sumQ, sumM := 0.0, 0.0
+	for i := range qmc {
+		qmc[i], mc[i] = v.Next(), float64From(src)
+		sumQ += qmc[i] * qmc[i]
+		sumM += mc[i] * mc[i]
+	}
+	if dq, dm := starDiscrepancy(qmc), starDiscrepancy(mc); dq*10 > dm {
+		t.Errorf("van der Corput discrepancy %v is not well below uniform random %v", dq, dm)
+	}
+	// The integral of x^2 over [0, 1) is 1/3.
+	if eq, em := math.Abs(sumQ/n-1.0/3), math.Abs(sumM/n-1.0/3); eq > 1e-3 || eq > em {
+		t.Errorf("integration error %v with van der Corput, %v with uniform random", eq, em)
+	}
+}
@@ -0,0 +1,32 @@
+package milkrandom
+
+// RandomWalk represents a one-dimensional random walk whose steps are +1 or -1 with equal probability.
+type RandomWalk struct {
+	src      Source
+	position float64
+}
+
+// NewRandomWalk creates a new RandomWalk starting at position 0 and driven by src.
+func NewRandomWalk(src Source) *RandomWalk {
+	return &RandomWalk{src: src}
+}
+
+// Step moves the walk by one step and returns the step taken, either +1 or -1.
+func (w *RandomWalk) Step() float64 {
+	step := 1.0
+	if w.src.Uint64()>>63 == 0 {
+		step = -1.0
+	}
+	w.position += step
+	return step
+}
+
+// Position returns the current position of the walk.
+func (w *RandomWalk) Position() float64 {
+	return w.position
+}
+
+// Reset moves the walk back to position 0. The underlying source is not reseeded.
+func (w *RandomWalk) Reset() {
+	w.position = 0
+}
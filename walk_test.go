@@ -0,0 +1,47 @@
+package milkrandom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRandomWalkReproducible(t *testing.T) {
+	a := NewRandomWalk(newTestSource(5))
+	b := NewRandomWalk(newTestSource(5))
+	for i := 0; i < 1000; i++ {
+		if sa, sb := a.Step(), b.Step(); sa != sb {
+			t.Fatalf("step %d: %v != %v", i, sa, sb)
+		}
+	}
+	if a.Position() != b.Position() {
+		t.Errorf("positions differ: %v != %v", a.Position(), b.Position())
+	}
+	a.Reset()
+	if a.Position() != 0 {
+		t.Errorf("Position after Reset = %v, want 0", a.Position())
+	}
+}
+
+func TestRandomWalkMoments(t *testing.T) {
+	const steps, walks = 100, 20000
+	w := NewRandomWalk(newTestSource(1))
+	var sum, sumSq float64
+	for i := 0; i < walks; i++ {
+		w.Reset()
+		for j := 0; j < steps; j++ {
+			if s := w.Step(); s != 1 && s != -1 {
+				t.Fatalf("Step() = %v, want +1 or -1", s)
+			}
+		}
+		sum += w.Position()
+		sumSq += w.Position() * w.Position()
+	}
+	mean := sum / walks
+	variance := sumSq/walks - mean*mean
+	if math.Abs(mean) > 0.3 {
+		t.Errorf("mean position = %v, want about 0", mean)
+	}
+	if math.Abs(variance-steps) > 0.05*steps {
+		t.Errorf("variance of position = %v, want about %d", variance, steps)
+	}
+}
@@ -0,0 +1,376 @@
+package milkrandom
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+	"sort"
+)
+
+// cumulativeWeights returns the running totals of weights. It returns an error if any weight
+// is negative or not finite, or if the total is not positive.
+func cumulativeWeights(weights []float64) ([]float64, error) {
+	cum := make([]float64, len(weights))
+	total := 0.0
+	for i, w := range weights {
+		if !(w >= 0) || math.IsInf(w, 1) {
+			return nil, errors.New("milkrandom: weights must be finite and non-negative")
+		}
+		total += w
+		cum[i] = total
+	}
+	if !(total > 0) || math.IsInf(total, 1) {
+		return nil, errors.New("milkrandom: weights must have a positive finite sum")
+	}
+	return cum, nil
+}
+
+// pickCumulative returns an index chosen with probability proportional to its weight,
+// given the running totals produced by cumulativeWeights.
+func pickCumulative(src Source, cum []float64) int {
+	r := float64From(src) * cum[len(cum)-1]
+	i := sort.Search(len(cum), func(i int) bool { return cum[i] > r })
+	if i == len(cum) { // guard against rounding at the upper edge
+		i = len(cum) - 1
+	}
+	return i
+}
+
+// Mixture is a mixture distribution that first picks one of its components by weight
+// and then draws a value from that component.
+type Mixture struct {
+	components []func(Source) float64
+	cum        []float64
+}
+
+// NewMixture creates a new Mixture from the given components and their weights.
+// Weights need not sum to 1. It returns an error if the lengths differ or the weights are invalid.
+func NewMixture(components []func(Source) float64, weights []float64) (*Mixture, error) {
+	if len(components) != len(weights) {
+		return nil, errors.New("milkrandom: components and weights must have the same length")
+	}
+	cum, err := cumulativeWeights(weights)
+	if err != nil {
+		return nil, err
+	}
+	return &Mixture{components: components, cum: cum}, nil
+}
+
+// Sample draws a value from the mixture using src.
+func (m *Mixture) Sample(src Source) float64 {
+	return m.components[pickCumulative(src, m.cum)](src)
+}
+
+// HierarchyNode is a node of a weighted category tree used by NewHierarchicalSampler.
+// Weight is the node's weight relative to its siblings and is ignored for the root.
+type HierarchyNode struct {
+	Weight   float64
+	Children []HierarchyNode
+}
+
+// HierarchicalSampler samples root-to-leaf paths in a weighted category tree.
+type HierarchicalSampler struct {
+	root hierarchyLevel
+}
+
+// hierarchyLevel holds the cumulative child weights of one node.
+type hierarchyLevel struct {
+	cum      []float64
+	children []hierarchyLevel
+}
+
+// NewHierarchicalSampler creates a new HierarchicalSampler for the tree rooted at root.
+// It returns an error if the children of any node have invalid weights.
+func NewHierarchicalSampler(root HierarchyNode) (*HierarchicalSampler, error) {
+	level, err := newHierarchyLevel(root)
+	if err != nil {
+		return nil, err
+	}
+	return &HierarchicalSampler{root: level}, nil
+}
+
+func newHierarchyLevel(n HierarchyNode) (hierarchyLevel, error) {
+	if len(n.Children) == 0 {
+		return hierarchyLevel{}, nil
+	}
+	weights := make([]float64, len(n.Children))
+	for i, c := range n.Children {
+		weights[i] = c.Weight
+	}
+	cum, err := cumulativeWeights(weights)
+	if err != nil {
+		return hierarchyLevel{}, err
+	}
+	level := hierarchyLevel{cum: cum, children: make([]hierarchyLevel, len(n.Children))}
+	for i, c := range n.Children {
+		if level.children[i], err = newHierarchyLevel(c); err != nil {
+			return hierarchyLevel{}, err
+		}
+	}
+	return level, nil
+}
+
+// Sample returns a path from the root to a leaf as the sequence of chosen child indices.
+// At each node a child is chosen with probability proportional to its weight, so a leaf is
+// reached with probability equal to the product of the probabilities along its path.
+// The path is empty if the root has no children.
+func (h *HierarchicalSampler) Sample(src Source) []int {
+	var path []int
+	level := &h.root
+	for len(level.children) > 0 {
+		i := pickCumulative(src, level.cum)
+		path = append(path, i)
+		level = &level.children[i]
+	}
+	return path
+}
+
+// aliasTable draws indices in constant time with probability proportional to their weights
+// using Vose's alias method.
+type aliasTable struct {
+	prob  []float64
+	alias []int
+}
+
+// newAliasTable builds an alias table for weights, which must already have been validated
+// and have a positive finite sum.
+func newAliasTable(weights []float64, total float64) aliasTable {
+	n := len(weights)
+	t := aliasTable{prob: make([]float64, n), alias: make([]int, n)}
+	scaled := make([]float64, n)
+	var small, large []int
+	for i, w := range weights {
+		scaled[i] = w * float64(n) / total
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+	for len(small) > 0 && len(large) > 0 {
+		s, l := small[len(small)-1], large[len(large)-1]
+		small = small[:len(small)-1]
+		t.prob[s], t.alias[s] = scaled[s], l
+		scaled[l] -= 1 - scaled[s]
+		if scaled[l] < 1 {
+			large = large[:len(large)-1]
+			small = append(small, l)
+		}
+	}
+	// Whatever is left is 1 up to rounding.
+	for _, i := range large {
+		t.prob[i], t.alias[i] = 1, i
+	}
+	for _, i := range small {
+		t.prob[i], t.alias[i] = 1, i
+	}
+	return t
+}
+
+// pick returns an index chosen with probability proportional to its weight.
+func (t aliasTable) pick(src Source) int {
+	i := intn(src, len(t.prob))
+	if float64From(src) < t.prob[i] {
+		return i
+	}
+	return t.alias[i]
+}
+
+// SampleByCounts returns an index chosen with probability proportional to its count.
+// Counts are summed as integers, so large counts are weighted exactly rather than rounded
+// to float64. It panics if any count is negative or if the counts do not have a positive
+// total that fits in an int64.
+func SampleByCounts(src Source, counts []int64) int {
+	var total int64
+	for _, c := range counts {
+		if c < 0 {
+			panic("milkrandom: negative count passed to SampleByCounts")
+		}
+		if total > math.MaxInt64-c {
+			panic("milkrandom: total of counts passed to SampleByCounts overflows int64")
+		}
+		total += c
+	}
+	if total == 0 {
+		panic("milkrandom: counts passed to SampleByCounts have a zero total")
+	}
+	r := int64(uint64n(src, uint64(total)))
+	for i, c := range counts {
+		if r < c {
+			return i
+		}
+		r -= c
+	}
+	panic("unreachable")
+}
+
+// WeightedRoundRobinOrder returns a sequence in which each index i appears weights[i] times,
+// spread out by smooth weighted round-robin: at every step each index gains its weight in credit,
+// the index with the most credit is emitted and pays back the total weight. Indices with equal
+// credit are chosen between uniformly at random using src, so different generators or seeds give
+// different but equally smooth orders. It panics if any weight is negative.
+func WeightedRoundRobinOrder(src Source, weights []int) []int {
+	total := 0
+	for _, w := range weights {
+		if w < 0 {
+			panic("milkrandom: negative weight passed to WeightedRoundRobinOrder")
+		}
+		total += w
+	}
+	order := make([]int, 0, total)
+	credit := make([]int, len(weights))
+	for len(order) < total {
+		best, ties := -1, 0
+		for i, w := range weights {
+			if w == 0 {
+				continue
+			}
+			credit[i] += w
+			switch {
+			case best < 0 || credit[i] > credit[best]:
+				best, ties = i, 1
+			case credit[i] == credit[best]:
+				ties++
+				if intn(src, ties) == 0 {
+					best = i
+				}
+			}
+		}
+		credit[best] -= total
+		order = append(order, best)
+	}
+	return order
+}
+
+// FenwickSampler draws indices with probability proportional to weights that may change between
+// draws. The weights are kept in a Fenwick (binary indexed) tree, so both Update and Sample take
+// O(log n) time, where an alias table would need an O(n) rebuild after every change.
+//
+// Update applies the change in weight to the tree nodes above it, so rounding errors accumulate in
+// the tree over many updates and Total can drift slightly from the exact sum of the weights. Sample
+// never returns an index with weight 0: if rounding steers it onto one, it rebuilds the tree from
+// the weights in O(n) time and draws again. A FenwickSampler is not safe for concurrent use.
+type FenwickSampler struct {
+	weights  []float64
+	tree     []float64 // 1-based; tree[i] holds the sum of weights (i - i&-i, i]
+	positive int       // number of positive weights
+}
+
+// NewFenwickSampler creates a new FenwickSampler with the given initial weights, which may all be
+// zero until updated. It returns an error if any weight is negative or not finite, or if the weights
+// sum to infinity.
+func NewFenwickSampler(weights []float64) (*FenwickSampler, error) {
+	f := &FenwickSampler{
+		weights: make([]float64, len(weights)),
+		tree:    make([]float64, len(weights)+1),
+	}
+	for i, w := range weights {
+		if !(w >= 0) || math.IsInf(w, 1) {
+			return nil, errors.New("milkrandom: weights must be finite and non-negative")
+		}
+		f.weights[i] = w
+		if w > 0 {
+			f.positive++
+		}
+	}
+	f.rebuild()
+	if math.IsInf(f.Total(), 1) {
+		return nil, errors.New("milkrandom: weights must have a finite sum")
+	}
+	return f, nil
+}
+
+// rebuild recomputes the tree from the weights in O(n) time, discarding accumulated rounding errors.
+func (f *FenwickSampler) rebuild() {
+	for i := range f.tree {
+		f.tree[i] = 0
+	}
+	for i, w := range f.weights {
+		f.tree[i+1] += w
+		if j := i + 1 + (i+1)&-(i+1); j < len(f.tree) {
+			f.tree[j] += f.tree[i+1]
+		}
+	}
+}
+
+// Len returns the number of weights.
+func (f *FenwickSampler) Len() int {
+	return len(f.weights)
+}
+
+// Weight returns the current weight of index i.
+func (f *FenwickSampler) Weight(i int) float64 {
+	return f.weights[i]
+}
+
+// Total returns the sum of all weights as kept in the tree, which after many updates can differ
+// from the exact sum by accumulated rounding.
+func (f *FenwickSampler) Total() float64 {
+	total := 0.0
+	for i := len(f.weights); i > 0; i -= i & -i {
+		total += f.tree[i]
+	}
+	return total
+}
+
+// Update sets the weight of index i. It panics if i is out of range, if weight is negative or not
+// finite, or if the new weight would make the weights sum to infinity.
+func (f *FenwickSampler) Update(i int, weight float64) {
+	if i < 0 || i >= len(f.weights) {
+		panic("milkrandom: index passed to FenwickSampler.Update is out of range")
+	}
+	if !(weight >= 0) || math.IsInf(weight, 1) {
+		panic("milkrandom: weight passed to FenwickSampler.Update must be finite and non-negative")
+	}
+	if math.IsInf(f.Total()-f.weights[i]+weight, 1) {
+		panic("milkrandom: weight passed to FenwickSampler.Update makes the sum of the weights infinite")
+	}
+	if f.weights[i] > 0 {
+		f.positive--
+	}
+	if weight > 0 {
+		f.positive++
+	}
+	delta := weight - f.weights[i]
+	f.weights[i] = weight
+	for j := i + 1; j < len(f.tree); j += j & -j {
+		f.tree[j] += delta
+	}
+}
+
+// Sample returns an index chosen with probability proportional to its current weight, descending
+// the tree from the highest power of two. Indices with weight 0 are never returned.
+// It panics if every weight is 0.
+func (f *FenwickSampler) Sample(src Source) int {
+	if f.positive == 0 {
+		panic("milkrandom: FenwickSampler weights have a zero total")
+	}
+	for rebuilt := false; ; {
+		if i, ok := f.descend(src); ok {
+			return i
+		}
+		if !rebuilt {
+			f.rebuild()
+			rebuilt = true
+		}
+	}
+}
+
+// descend draws a point in [0, Total()) and returns the index whose weight covers it. ok is false
+// if rounding in the tree left the total non-positive or led the descent onto a zero weight.
+func (f *FenwickSampler) descend(src Source) (i int, ok bool) {
+	total := f.Total()
+	if !(total > 0) {
+		return 0, false
+	}
+	r := float64From(src) * total
+	pos := 0
+	for step := 1 << bits.Len(uint(len(f.weights))); step > 0; step >>= 1 {
+		if next := pos + step; next < len(f.tree) && f.tree[next] <= r {
+			pos = next
+			r -= f.tree[next]
+		}
+	}
+	// pos is the number of leading weights whose sum is at most r.
+	return pos, pos < len(f.weights) && f.weights[pos] > 0
+}
@@ -0,0 +1,321 @@
+package milkrandom
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestMixtureBimodal(t *testing.T) {
+	picked := [2]int{}
+	left := func(src Source) float64 { picked[0]++; return -5 + NormFloat64(src) }
+	right := func(src Source) float64 { picked[1]++; return 5 + NormFloat64(src) }
+	m, err := NewMixture([]func(Source) float64{left, right}, []float64{3, 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := newTestSource(1)
+	const n = 100000
+	bins := make([]int, 12) // [-6, 6) in steps of 1
+	for i := 0; i < n; i++ {
+		v := m.Sample(src)
+		if b := int(math.Floor(v)) + 6; b >= 0 && b < len(bins) {
+			bins[b]++
+		}
+	}
+	if frac := float64(picked[0]) / n; math.Abs(frac-0.3) > 0.01 {
+		t.Errorf("first component chosen %v of the time, want 0.3", frac)
+	}
+	// Peaks at -5 and 5 with a trough at 0.
+	leftPeak, trough, rightPeak := bins[1]+bins[0], bins[5]+bins[6], bins[10]+bins[11]
+	if !(leftPeak > 10*trough && rightPeak > 10*trough && rightPeak > leftPeak) {
+		t.Errorf("histogram %v is not bimodal with the heavier mode on the right", bins)
+	}
+}
+
+func TestNewMixtureInvalid(t *testing.T) {
+	c := func(Source) float64 { return 0 }
+	if _, err := NewMixture([]func(Source) float64{c, c}, []float64{1}); err == nil {
+		t.Error("NewMixture accepted mismatched lengths")
+	}
+	if _, err := NewMixture([]func(Source) float64{c}, []float64{-1}); err == nil {
+		t.Error("NewMixture accepted a negative weight")
+	}
+	if _, err := NewMixture(nil, nil); err == nil {
+		t.Error("NewMixture accepted no components")
+	}
+}
+
+func TestHierarchicalSamplerLeafFrequencies(t *testing.T) {
+	// Leaves and the product of the probabilities along their paths:
+	//	[0 0] 1/4 * 1/3, [0 1] 1/4 * 2/3, [1] 1/2, [2 0] 1/4.
+	root := HierarchyNode{Children: []HierarchyNode{
+		{Weight: 1, Children: []HierarchyNode{{Weight: 1}, {Weight: 2}}},
+		{Weight: 2},
+		{Weight: 1, Children: []HierarchyNode{{Weight: 5}}},
+	}}
+	want := map[string]float64{"[0 0]": 1.0 / 12, "[0 1]": 2.0 / 12, "[1]": 0.5, "[2 0]": 0.25}
+	h, err := NewHierarchicalSampler(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := newTestSource(1)
+	const n = 100000
+	counts := make(map[string]int)
+	for i := 0; i < n; i++ {
+		counts[fmt.Sprint(h.Sample(src))]++
+	}
+	for path := range counts {
+		if _, ok := want[path]; !ok {
+			t.Errorf("Sample returned %s, which is not a root-to-leaf path", path)
+		}
+	}
+	for path, p := range want {
+		if got := float64(counts[path]) / n; math.Abs(got-p) > 0.01 {
+			t.Errorf("leaf %s reached with frequency %v, want %v", path, got, p)
+		}
+	}
+
+	a, b := newTestSource(7), newTestSource(7)
+	for i := 0; i < 100; i++ {
+		if pa, pb := fmt.Sprint(h.Sample(a)), fmt.Sprint(h.Sample(b)); pa != pb {
+			t.Fatalf("sample %d is %s and %s for sources with the same seed", i, pa, pb)
+		}
+	}
+}
+
+func TestNewHierarchicalSamplerInvalid(t *testing.T) {
+	bad := HierarchyNode{Children: []HierarchyNode{
+		{Weight: 1, Children: []HierarchyNode{{Weight: 1}, {Weight: -1}}},
+	}}
+	if _, err := NewHierarchicalSampler(bad); err == nil {
+		t.Error("NewHierarchicalSampler accepted a negative weight below the root")
+	}
+	h, err := NewHierarchicalSampler(HierarchyNode{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path := h.Sample(newTestSource(1)); len(path) != 0 {
+		t.Errorf("Sample on a tree without children = %v, want an empty path", path)
+	}
+}
+
+func TestSampleByCountsFrequencies(t *testing.T) {
+	counts := []int64{1, 0, 2, 3, 4}
+	src := newTestSource(1)
+	const n = 100000
+	got := make([]int, len(counts))
+	for i := 0; i < n; i++ {
+		got[SampleByCounts(src, counts)]++
+	}
+	if got[1] != 0 {
+		t.Errorf("index with count 0 was sampled %d times", got[1])
+	}
+	for i, c := range counts {
+		if p := float64(got[i]) / n; math.Abs(p-float64(c)/10) > 0.01 {
+			t.Errorf("index %d sampled with frequency %v, want %v", i, p, float64(c)/10)
+		}
+	}
+}
+
+func TestSampleByCountsLargeCounts(t *testing.T) {
+	// 2^61 + 1 is not a float64, so summing these counts as floats would lose index 1.
+	counts := []int64{1 << 61, 1, 1 << 61}
+	n := uint64(1<<62 + 1)
+	// Draws at or above 2^64 mod n = 2^62 - 3 are accepted and reduced mod n.
+	for r, want := range map[uint64]int{1<<61 - 1: 0, 1 << 61: 1, 1<<61 + 1: 2} {
+		src := seqSource{r + n}
+		if got := SampleByCounts(&src, counts); got != want {
+			t.Errorf("SampleByCounts with offset %d = %d, want %d", r, got, want)
+		}
+	}
+}
+
+func TestSampleByCountsInvalid(t *testing.T) {
+	for _, counts := range [][]int64{nil, {0, 0}, {1, -1}, {math.MaxInt64, 1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("SampleByCounts(%v) did not panic", counts)
+				}
+			}()
+			SampleByCounts(newTestSource(1), counts)
+		}()
+	}
+}
+
+func TestWeightedRoundRobinOrder(t *testing.T) {
+	weights := []int{5, 1, 0, 3, 1}
+	total := 10
+	order := WeightedRoundRobinOrder(newTestSource(1), weights)
+	if len(order) != total {
+		t.Fatalf("order %v has %d entries, want %d", order, len(order), total)
+	}
+	counts := make([]int, len(weights))
+	for m, i := range order {
+		counts[i]++
+		// Smoothness: every prefix holds each index about in proportion to its weight.
+		for j, w := range weights {
+			if want := float64((m+1)*w) / float64(total); math.Abs(float64(counts[j])-want) > 1 {
+				t.Errorf("prefix %v holds index %d %d times, want about %v", order[:m+1], j, counts[j], want)
+			}
+		}
+	}
+	for i, w := range weights {
+		if counts[i] != w {
+			t.Errorf("index %d appears %d times, want %d", i, counts[i], w)
+		}
+	}
+
+	a := WeightedRoundRobinOrder(newTestSource(7), weights)
+	b := WeightedRoundRobinOrder(newTestSource(7), weights)
+	if fmt.Sprint(a) != fmt.Sprint(b) {
+		t.Errorf("orders %v and %v differ for sources with the same seed", a, b)
+	}
+	// Equal weights tie at every step, so the tie-break decides the order.
+	orders := make(map[string]bool)
+	for seed := uint64(1); seed <= 50; seed++ {
+		orders[fmt.Sprint(WeightedRoundRobinOrder(newTestSource(seed), []int{1, 1, 1}))] = true
+	}
+	if len(orders) != 6 {
+		t.Errorf("50 seeds gave %d of the 6 orders of three equal weights", len(orders))
+	}
+	if got := WeightedRoundRobinOrder(newTestSource(1), []int{0, 0}); len(got) != 0 {
+		t.Errorf("all-zero weights gave %v, want an empty order", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("WeightedRoundRobinOrder accepted a negative weight")
+		}
+	}()
+	WeightedRoundRobinOrder(newTestSource(1), []int{1, -1})
+}
+
+// checkFenwickFrequencies draws n samples from f and compares the frequencies with its weights.
+func checkFenwickFrequencies(t *testing.T, src Source, f *FenwickSampler, n int) {
+	t.Helper()
+	total := 0.0
+	for i := 0; i < f.Len(); i++ {
+		total += f.Weight(i)
+	}
+	counts := make([]int, f.Len())
+	for k := 0; k < n; k++ {
+		counts[f.Sample(src)]++
+	}
+	for i, c := range counts {
+		want := f.Weight(i) / total
+		if want == 0 && c != 0 {
+			t.Fatalf("index %d with weight 0 drawn %d times", i, c)
+		}
+		if got := float64(c) / float64(n); math.Abs(got-want) > 5*math.Sqrt(want*(1-want)/float64(n))+1e-9 {
+			t.Errorf("index %d drawn with frequency %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestFenwickSampler(t *testing.T) {
+	src := newTestSource(1)
+	weights := []float64{1, 0, 3, 2, 0, 4, 0.5, 9.5}
+	f, err := NewFenwickSampler(weights)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Len() != len(weights) || f.Total() != 20 {
+		t.Fatalf("Len() = %d, Total() = %v; want %d, 20", f.Len(), f.Total(), len(weights))
+	}
+	checkFenwickFrequencies(t, src, f, 100000)
+
+	// Frequencies follow the weights after each round of updates.
+	for round := 0; round < 5; round++ {
+		for k := 0; k < 20; k++ {
+			w := float64(intn(src, 5))
+			i := intn(src, len(weights))
+			f.Update(i, w)
+			weights[i] = w
+		}
+		if weights[0] == 0 {
+			f.Update(0, 1)
+			weights[0] = 1
+		}
+		sum := 0.0
+		for i, w := range weights {
+			if f.Weight(i) != w {
+				t.Fatalf("Weight(%d) = %v, want %v", i, f.Weight(i), w)
+			}
+			sum += w
+		}
+		if f.Total() != sum {
+			t.Fatalf("Total() = %v, want %v", f.Total(), sum)
+		}
+		checkFenwickFrequencies(t, src, f, 50000)
+	}
+
+	// Many small random updates leave rounding errors in the tree; a zero weight is still never drawn.
+	g, _ := NewFenwickSampler(make([]float64, 100))
+	for k := 0; k < 100000; k++ {
+		g.Update(intn(src, 100), float64From(src)*1e-3)
+	}
+	for i := 0; i < 100; i += 3 {
+		g.Update(i, 0)
+	}
+	checkFenwickFrequencies(t, src, g, 50000)
+}
+
+func TestFenwickSamplerRounding(t *testing.T) {
+	// Adding 1 to 1e16 is lost to rounding in the tree node covering both weights, so removing
+	// 1e16 leaves a total of 0 even though index 1 still has weight 1.
+	f, _ := NewFenwickSampler([]float64{1e16, 1})
+	f.Update(0, 0)
+	src := newTestSource(1)
+	for k := 0; k < 10; k++ {
+		if i := f.Sample(src); i != 1 {
+			t.Fatalf("Sample() = %d, want the only positive weight 1", i)
+		}
+	}
+
+	// The other way round, rounding leaves a positive total when every weight is 0.
+	g, _ := NewFenwickSampler([]float64{0.1, 0.2, 0.3})
+	g.Update(2, 0)
+	g.Update(0, 0)
+	g.Update(1, 0)
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Sample with every weight 0 and Total() = %v did not panic", g.Total())
+		}
+	}()
+	g.Sample(src)
+}
+
+func TestFenwickSamplerInvalid(t *testing.T) {
+	for _, w := range [][]float64{{1, -1}, {math.NaN()}, {math.Inf(1)}, {1e308, 1e308}} {
+		if _, err := NewFenwickSampler(w); err == nil {
+			t.Errorf("NewFenwickSampler(%v) accepted invalid weights", w)
+		}
+	}
+	f, _ := NewFenwickSampler([]float64{0, 0})
+	big, _ := NewFenwickSampler([]float64{1e308, 0})
+	for _, c := range []struct {
+		name string
+		fn   func()
+	}{
+		{"Sample with zero weights", func() { f.Sample(newTestSource(1)) }},
+		{"Update out of range", func() { f.Update(2, 1) }},
+		{"Update with a negative weight", func() { f.Update(0, -1) }},
+		{"Update with NaN", func() { f.Update(0, math.NaN()) }},
+		{"Update to an infinite sum", func() { big.Update(1, 1e308) }},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", c.name)
+				}
+			}()
+			c.fn()
+		}()
+	}
+	// The rejected update left the weights as they were, so sampling still works.
+	if big.Weight(1) != 0 || big.Sample(newTestSource(1)) != 0 {
+		t.Errorf("rejected update changed the sampler: Weight(1) = %v", big.Weight(1))
+	}
+}
@@ -3,15 +3,20 @@ package xoshiro256starstar
 
 import (
 	"encoding/binary"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"math/bits"
+	"strconv"
 	"sync"
 	"time"
 )
 
 // Xoshiro256StarStar represents the state of a xoshiro256** random number generator.
 type Xoshiro256StarStar struct {
-	state [4]uint64
+	state  [4]uint64
+	seed   uint64 // last value passed to Seed, valid while seeded is set
+	seeded bool
 }
 
 // SafeXoshiro256StarStar represents the state of a xoshiro256** random number generator with a mutex to make it safe for concurrent use.
@@ -20,28 +25,60 @@ type SafeXoshiro256StarStar struct {
 	mu sync.Mutex
 }
 
+// Both generator types implement milkrandom.Source, which this package does not import.
+var (
+	_ interface{ Uint64() uint64 } = (*Xoshiro256StarStar)(nil)
+	_ interface{ Uint64() uint64 } = (*SafeXoshiro256StarStar)(nil)
+)
+
+// DefaultWarmup is the number of outputs New and NewSafe discard after seeding.
+const DefaultWarmup = 10
+
 // New creates a new xoshiro256StarStar instance seeded with the current time.
+// The first DefaultWarmup outputs are discarded to "warm up" the generator.
 func New() *Xoshiro256StarStar {
-	x := &Xoshiro256StarStar{}
-	x.Seed(uint64(time.Now().UnixNano()))
-	// "Warm up" the generator
-	for i := 0; i < 10; i++ {
-		x.Uint64()
-	}
+	x := NewWithWarmup(uint64(time.Now().UnixNano()), DefaultWarmup)
+	x.seeded = false
 	return x
 }
 
 // NewSafe creates a new safe xoshiro256StarStar instance seeded with the current time.
+// The first DefaultWarmup outputs are discarded to "warm up" the generator.
 func NewSafe() *SafeXoshiro256StarStar {
-	x := &SafeXoshiro256StarStar{}
-	x.Seed(uint64(time.Now().UnixNano()))
-	// "Warm up" the generator
-	for i := 0; i < 10; i++ {
+	return NewSafeFrom(New())
+}
+
+// NewWithWarmup creates a new xoshiro256StarStar instance seeded with seed that discards the first rounds outputs.
+// With rounds set to 0 the stream starts right after seeding, matching a reference implementation seeded the same way.
+func NewWithWarmup(seed uint64, rounds int) *Xoshiro256StarStar {
+	x := &Xoshiro256StarStar{}
+	x.Seed(seed)
+	for i := 0; i < rounds; i++ {
 		x.Uint64()
 	}
 	return x
 }
 
+// NewSafeWithWarmup creates a new safe xoshiro256StarStar instance seeded with seed that discards the first rounds outputs.
+func NewSafeWithWarmup(seed uint64, rounds int) *SafeXoshiro256StarStar {
+	return NewSafeFrom(NewWithWarmup(seed, rounds))
+}
+
+// NewSafeFrom creates a new safe xoshiro256StarStar instance that continues from the current state of src.
+// src itself is not modified and remains unsafe for concurrent use.
+func NewSafeFrom(src *Xoshiro256StarStar) *SafeXoshiro256StarStar {
+	return &SafeXoshiro256StarStar{Xoshiro256StarStar: *src}
+}
+
+// NewSnapshot creates a new Xoshiro256StarStar instance seeded with seed and returns it together with its marshaled
+// initial state, so the exact starting point can later be restored with Unmarshal. Unlike New, no warm-up outputs are discarded.
+func NewSnapshot(seed uint64) (*Xoshiro256StarStar, []byte) {
+	x := &Xoshiro256StarStar{}
+	x.Seed(seed)
+	state, _ := x.Marshal() // Marshal never fails
+	return x, state
+}
+
 // State returns the current state of the random number generator.
 func (x *Xoshiro256StarStar) State() [4]uint64 {
 	return x.state
@@ -54,9 +91,27 @@ func (x *SafeXoshiro256StarStar) State() [4]uint64 {
 	return x.Xoshiro256StarStar.State()
 }
 
+// Checksum returns a 64-bit hash of the current state of the random number generator.
+// Identical states produce identical checksums, so it can be used to compare generators without exposing their state.
+func (x *Xoshiro256StarStar) Checksum() uint64 {
+	var s uint64
+	for _, v := range x.state {
+		s ^= v
+		s = splitmix64(&s)
+	}
+	return splitmix64(&s)
+}
+
+// Checksum returns a 64-bit hash of the current state of the random number generator, which is safe for concurrent use.
+func (x *SafeXoshiro256StarStar) Checksum() uint64 {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.Xoshiro256StarStar.Checksum()
+}
+
 // Reset resets the state of the random number generator to the seed value.
 func (x *Xoshiro256StarStar) Reset() {
-	x.Seed(uint64(time.Now().UnixNano()))
+	x.seedClock()
 }
 
 // Reset resets the state of the random number generator to the seed value, which is safe for concurrent use.
@@ -90,6 +145,7 @@ func (x *Xoshiro256StarStar) Unmarshal(data []byte) error {
 	for i := range x.state {
 		x.state[i] = binary.LittleEndian.Uint64(data[i*8:])
 	}
+	x.seeded = false
 	return nil
 }
 
@@ -100,18 +156,57 @@ func (x *SafeXoshiro256StarStar) Unmarshal(data []byte) error {
 	return x.Xoshiro256StarStar.Unmarshal(data)
 }
 
+// MarshalJSON returns a canonical JSON encoding of the current state of the random number generator.
+// The state words are written as fixed-width zero-padded hexadecimal, so equal states always encode to identical bytes.
+func (x *Xoshiro256StarStar) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`{"state":["%016x","%016x","%016x","%016x"]}`,
+		x.state[0], x.state[1], x.state[2], x.state[3])), nil
+}
+
+// MarshalJSON returns a canonical JSON encoding of the current state of the random number generator, which is safe for concurrent use.
+func (x *SafeXoshiro256StarStar) MarshalJSON() ([]byte, error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.Xoshiro256StarStar.MarshalJSON()
+}
+
+// UnmarshalJSON sets the state of the random number generator to the state represented by the JSON input data.
+func (x *Xoshiro256StarStar) UnmarshalJSON(data []byte) error {
+	var v struct {
+		State []string `json:"state"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if len(v.State) != len(x.state) {
+		return errors.New("xoshiro256starstar: invalid JSON state")
+	}
+	var state [4]uint64
+	for i, s := range v.State {
+		w, err := parseHex64(s)
+		if err != nil {
+			return err
+		}
+		state[i] = w
+	}
+	x.state = state
+	x.seeded = false
+	return nil
+}
+
+// UnmarshalJSON sets the state of the random number generator to the state represented by the JSON input data, which is safe for concurrent use.
+func (x *SafeXoshiro256StarStar) UnmarshalJSON(data []byte) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.Xoshiro256StarStar.UnmarshalJSON(data)
+}
+
 // Seed initializes the state of the random number generator with the given seed value.
 func (x *Xoshiro256StarStar) Seed(seed uint64) {
+	x.seed, x.seeded = seed, seed != 0
 	if seed == 0 { // Seed with current time if seed is 0
 		seed = uint64(time.Now().UnixNano())
 	}
-	splitmix64 := func(s *uint64) uint64 {
-		*s += 0x9e3779b97f4a7c15
-		z := *s
-		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
-		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
-		return z ^ (z >> 31)
-	}
 	s := seed
 	x.state[0] = splitmix64(&s)
 	x.state[1] = splitmix64(&s)
@@ -126,6 +221,28 @@ func (x *SafeXoshiro256StarStar) Seed(seed uint64) {
 	x.Xoshiro256StarStar.Seed(seed)
 }
 
+// SeedN initializes the state of the random number generator from multiple seed values.
+// The values are folded together with SplitMix64 mixing, so the same sequence of values always yields the same state.
+func (x *Xoshiro256StarStar) SeedN(seeds ...uint64) {
+	var s uint64
+	for _, v := range seeds {
+		s ^= v
+		s = splitmix64(&s)
+	}
+	x.state[0] = splitmix64(&s)
+	x.state[1] = splitmix64(&s)
+	x.state[2] = splitmix64(&s)
+	x.state[3] = splitmix64(&s)
+	x.seeded = false
+}
+
+// SeedN initializes the state of the random number generator from multiple seed values, which is safe for concurrent use.
+func (x *SafeXoshiro256StarStar) SeedN(seeds ...uint64) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.Xoshiro256StarStar.SeedN(seeds...)
+}
+
 // Uint64 generates a random 64-bit unsigned integer.
 func (x *Xoshiro256StarStar) Uint64() uint64 {
 	result := bits.RotateLeft64(x.state[1]*5, 7) * 9
@@ -246,9 +363,10 @@ func (x *Xoshiro256StarStar) Int(n int) int {
 	if n&(n-1) == 0 { // n is 2^m, use mask
 		return int(x.Uint64() & uint64(n-1))
 	}
-	max := uint64((1 << 63) - 1 - (1<<63)%uint64(n))
+	// Reject the 2^64 mod n smallest draws so that the rest split evenly into n residues.
+	threshold := -uint64(n) % uint64(n)
 	v := x.Uint64()
-	for v > max {
+	for v < threshold {
 		v = x.Uint64()
 	}
 	return int(v % uint64(n))
@@ -264,6 +382,34 @@ func (x *SafeXoshiro256StarStar) Int(n int) int {
 	return x.Xoshiro256StarStar.Int(n)
 }
 
+// Uint64Range generates a random 64-bit unsigned integer in the range [min, max).
+// It panics if min >= max.
+func (x *Xoshiro256StarStar) Uint64Range(min, max uint64) uint64 {
+	if min >= max {
+		panic("xoshiro256starstar: invalid argument to Uint64Range")
+	}
+	n := max - min
+	if n&(n-1) == 0 { // n is 2^m, use mask
+		return min + x.Uint64()&(n-1)
+	}
+	threshold := -n % n // 2^64 mod n
+	v := x.Uint64()
+	for v < threshold {
+		v = x.Uint64()
+	}
+	return min + v%n
+}
+
+// Uint64Range generates a random 64-bit unsigned integer in the range [min, max), which is safe for concurrent use.
+func (x *SafeXoshiro256StarStar) Uint64Range(min, max uint64) uint64 {
+	if min >= max {
+		panic("xoshiro256starstar: invalid argument to Uint64Range")
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.Xoshiro256StarStar.Uint64Range(min, max)
+}
+
 // go:inline
 // Float64 generates a random float64 in the range [0.0, 1.0).
 func (x *Xoshiro256StarStar) Float64() float64 {
@@ -278,6 +424,40 @@ func (x *SafeXoshiro256StarStar) Float64() float64 {
 	return float64(x.Xoshiro256StarStar.Uint64()>>(64-53)) / (1 << 53)
 }
 
+// go:inline
+// Float64Closed generates a random float64 in the closed range [0.0, 1.0].
+// Unlike Float64, both 0.0 and 1.0 can be returned.
+func (x *Xoshiro256StarStar) Float64Closed() float64 {
+	return float64(x.Uint64()>>(64-53)) / (1<<53 - 1)
+}
+
+// go:inline
+// Float64Closed generates a random float64 in the closed range [0.0, 1.0], which is safe for concurrent use.
+func (x *SafeXoshiro256StarStar) Float64Closed() float64 {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return float64(x.Xoshiro256StarStar.Uint64()>>(64-53)) / (1<<53 - 1)
+}
+
+// Float64Bits generates a random float64 in the range [0.0, 1.0) using exactly mantissaBits bits of randomness.
+// The result is a multiple of 2^-mantissaBits. It panics if mantissaBits is not in [1, 53].
+func (x *Xoshiro256StarStar) Float64Bits(mantissaBits int) float64 {
+	if mantissaBits < 1 || mantissaBits > 53 {
+		panic("xoshiro256starstar: argument to Float64Bits is not in [1, 53]")
+	}
+	return float64(x.Uint64()>>(64-mantissaBits)) / float64(uint64(1)<<mantissaBits)
+}
+
+// Float64Bits generates a random float64 in the range [0.0, 1.0) using exactly mantissaBits bits of randomness, which is safe for concurrent use.
+func (x *SafeXoshiro256StarStar) Float64Bits(mantissaBits int) float64 {
+	if mantissaBits < 1 || mantissaBits > 53 {
+		panic("xoshiro256starstar: argument to Float64Bits is not in [1, 53]")
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.Xoshiro256StarStar.Float64Bits(mantissaBits)
+}
+
 // go:inline
 // Float32 generates a random float32 in the range [0.0, 1.0).
 func (x *Xoshiro256StarStar) Float32() float32 {
@@ -291,3 +471,253 @@ func (x *SafeXoshiro256StarStar) Float32() float32 {
 	defer x.mu.Unlock()
 	return float32(x.Xoshiro256StarStar.Uint32()>>(32-24)) / (1 << 24)
 }
+
+// Split generates a random 64-bit unsigned integer and a float64 in the range [0.0, 1.0) derived from the same draw.
+// The float is built from the high 53 bits exactly as Float64 builds it.
+func (x *Xoshiro256StarStar) Split() (uint64, float64) {
+	v := x.Uint64()
+	return v, float64(v>>(64-53)) / (1 << 53)
+}
+
+// Split generates a random 64-bit unsigned integer and a float64 derived from the same draw, which is safe for concurrent use.
+func (x *SafeXoshiro256StarStar) Split() (uint64, float64) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.Xoshiro256StarStar.Split()
+}
+
+// FillIntN fills dst with random integers in the range [0, n).
+// For n <= 2^16 each 64-bit draw is split into four 16-bit chunks, used from the least significant end,
+// and chunks at or above the largest multiple of n are rejected. Larger n fall back to one Int call per element,
+// so for those bounds the output matches calling Int repeatedly. It panics if n <= 0.
+func (x *Xoshiro256StarStar) FillIntN(dst []int, n int) {
+	if n <= 0 {
+		panic("xoshiro256starstar: argument to FillIntN is <= 0")
+	}
+	if n > 1<<16 {
+		for i := range dst {
+			dst[i] = x.Int(n)
+		}
+		return
+	}
+	limit := uint64(1<<16 - (1<<16)%n)
+	for i := 0; i < len(dst); {
+		v := x.Uint64()
+		for j := 0; j < 4 && i < len(dst); j++ {
+			c := v & 0xffff
+			v >>= 16
+			if c < limit {
+				dst[i] = int(c % uint64(n))
+				i++
+			}
+		}
+	}
+}
+
+// FillIntN fills dst with random integers in the range [0, n), holding the lock once for the whole slice, which is safe for concurrent use.
+func (x *SafeXoshiro256StarStar) FillIntN(dst []int, n int) {
+	if n <= 0 {
+		panic("xoshiro256starstar: argument to FillIntN is <= 0")
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.Xoshiro256StarStar.FillIntN(dst, n)
+}
+
+// ShuffleRange shuffles the elements with indices in [i, j) using the Fisher–Yates algorithm,
+// leaving all other elements in place. swap swaps the elements with indexes a and b.
+// It panics if i < 0 or j < i.
+func (x *Xoshiro256StarStar) ShuffleRange(i, j int, swap func(a, b int)) {
+	if i < 0 || j < i {
+		panic("xoshiro256starstar: invalid argument to ShuffleRange")
+	}
+	for k := j - 1; k > i; k-- {
+		swap(k, i+x.Int(k-i+1))
+	}
+}
+
+// ShuffleRange shuffles the elements with indices in [i, j), which is safe for concurrent use.
+// The lock is held for the whole shuffle, so swap must not use the generator.
+func (x *SafeXoshiro256StarStar) ShuffleRange(i, j int, swap func(a, b int)) {
+	if i < 0 || j < i {
+		panic("xoshiro256starstar: invalid argument to ShuffleRange")
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.Xoshiro256StarStar.ShuffleRange(i, j, swap)
+}
+
+// Stir deterministically reseeds the random number generator from its own state by passing every
+// state word through SeedN. The resulting state is a fixed function of the current one, so replays
+// stay reproducible, but the generator continues on a different stream than it would have without Stir.
+func (x *Xoshiro256StarStar) Stir() {
+	x.SeedN(x.state[0], x.state[1], x.state[2], x.state[3])
+}
+
+// Stir deterministically reseeds the random number generator from its own state, which is safe for concurrent use.
+func (x *SafeXoshiro256StarStar) Stir() {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.Xoshiro256StarStar.Stir()
+}
+
+// FullInt64 generates a random 64-bit signed integer over the full range [math.MinInt64, math.MaxInt64].
+// Unlike Int64, which is always non-negative, it reinterprets all 64 output bits in two's complement,
+// so outputs with the high bit set are negative and int64(uint64(v)) round-trips exactly.
+func (x *Xoshiro256StarStar) FullInt64() int64 {
+	return int64(x.Uint64())
+}
+
+// FullInt64 generates a random 64-bit signed integer over the full range, which is safe for concurrent use.
+func (x *SafeXoshiro256StarStar) FullInt64() int64 {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.Xoshiro256StarStar.FullInt64()
+}
+
+// Derive returns a new, independent random number generator seeded from the seed of this one combined with name.
+// The same seed and name always yield the same sub-generator, however many values this generator has produced
+// since Seed, while different names yield unrelated streams. If CurrentSeed reports no seed, the Checksum of the
+// current state is used in its place. The state of this generator is not advanced.
+func (x *Xoshiro256StarStar) Derive(name string) *Xoshiro256StarStar {
+	base, ok := x.CurrentSeed()
+	if !ok {
+		base = x.Checksum()
+	}
+	d := &Xoshiro256StarStar{}
+	d.SeedN(append([]uint64{base}, stringWords(name)...)...)
+	return d
+}
+
+// Derive returns a new, independent safe random number generator seeded from the seed of this one
+// combined with name, which is safe for concurrent use.
+func (x *SafeXoshiro256StarStar) Derive(name string) *SafeXoshiro256StarStar {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return &SafeXoshiro256StarStar{Xoshiro256StarStar: *x.Xoshiro256StarStar.Derive(name)}
+}
+
+// PermInto fills dst with a random permutation of the integers [0, len(dst)), overwriting its contents.
+// The permutation is built with the inside-out Fisher–Yates algorithm, one Int call per element after the first,
+// so the buffer can be reused across calls without allocating.
+func (x *Xoshiro256StarStar) PermInto(dst []int) {
+	if len(dst) == 0 {
+		return
+	}
+	dst[0] = 0
+	for i := 1; i < len(dst); i++ {
+		j := x.Int(i + 1)
+		dst[i] = dst[j]
+		dst[j] = i
+	}
+}
+
+// PermInto fills dst with a random permutation of the integers [0, len(dst)), which is safe for concurrent use.
+func (x *SafeXoshiro256StarStar) PermInto(dst []int) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.Xoshiro256StarStar.PermInto(dst)
+}
+
+// CurrentSeed returns the seed most recently passed to Seed, so a result can be reproduced by seeding with it again.
+// ok is false if the generator was instead seeded from the clock or with SeedN, or had its state loaded with Unmarshal or UnmarshalJSON.
+func (x *Xoshiro256StarStar) CurrentSeed() (seed uint64, ok bool) {
+	if !x.seeded {
+		return 0, false
+	}
+	return x.seed, true
+}
+
+// CurrentSeed returns the seed most recently passed to Seed, which is safe for concurrent use.
+func (x *SafeXoshiro256StarStar) CurrentSeed() (seed uint64, ok bool) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.Xoshiro256StarStar.CurrentSeed()
+}
+
+// IntExcept generates a random integer in the range [0, n) that is never exclude, with all other values equally likely.
+// It draws from [0, n-1) and shifts values at or above exclude up by one. It panics if n <= 1 or exclude is not in [0, n).
+func (x *Xoshiro256StarStar) IntExcept(n, exclude int) int {
+	if n <= 1 || exclude < 0 || exclude >= n {
+		panic("xoshiro256starstar: invalid argument to IntExcept")
+	}
+	v := x.Int(n - 1)
+	if v >= exclude {
+		v++
+	}
+	return v
+}
+
+// IntExcept generates a random integer in the range [0, n) that is never exclude, which is safe for concurrent use.
+func (x *SafeXoshiro256StarStar) IntExcept(n, exclude int) int {
+	if n <= 1 || exclude < 0 || exclude >= n {
+		panic("xoshiro256starstar: invalid argument to IntExcept")
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.Xoshiro256StarStar.IntExcept(n, exclude)
+}
+
+// Shuffle randomizes the order of n elements with the backward Fisher–Yates algorithm. swap swaps the elements with indexes a and b.
+// The draw sequence is fixed: for i from n-1 down to 1 it calls swap(i, j) with j = Int(i + 1), so any implementation making the
+// same Int calls on the same state reproduces the result. The order of swaps matches math/rand.Shuffle, though j is drawn by this
+// generator's Int rather than by math/rand. It is equivalent to ShuffleRange(0, n, swap). It panics if n < 0.
+func (x *Xoshiro256StarStar) Shuffle(n int, swap func(a, b int)) {
+	if n < 0 {
+		panic("xoshiro256starstar: argument to Shuffle is < 0")
+	}
+	for i := n - 1; i > 0; i-- {
+		swap(i, x.Int(i+1))
+	}
+}
+
+// Shuffle randomizes the order of n elements with the backward Fisher–Yates algorithm, which is safe for concurrent use.
+// The lock is held for the whole shuffle, so swap must not use the generator.
+func (x *SafeXoshiro256StarStar) Shuffle(n int, swap func(a, b int)) {
+	if n < 0 {
+		panic("xoshiro256starstar: argument to Shuffle is < 0")
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.Xoshiro256StarStar.Shuffle(n, swap)
+}
+
+// splitmix64 advances s and returns the next SplitMix64 output. It is used to expand seeds into the full state.
+func splitmix64(s *uint64) uint64 {
+	*s += 0x9e3779b97f4a7c15
+	z := *s
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// parseHex64 parses a 16-digit hexadecimal state word as written by MarshalJSON.
+func parseHex64(s string) (uint64, error) {
+	if len(s) != 16 {
+		return 0, errors.New("xoshiro256starstar: invalid JSON state")
+	}
+	v, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return 0, errors.New("xoshiro256starstar: invalid JSON state")
+	}
+	return v, nil
+}
+
+// stringWords splits s into little-endian 64-bit words, zero-padding the last one, followed by its length.
+func stringWords(s string) []uint64 {
+	words := make([]uint64, 0, len(s)/8+2)
+	for i := 0; i < len(s); i += 8 {
+		var w uint64
+		for j := i; j < len(s) && j < i+8; j++ {
+			w |= uint64(s[j]) << (8 * (j - i))
+		}
+		words = append(words, w)
+	}
+	return append(words, uint64(len(s)))
+}
+
+// seedClock seeds the random number generator from the current time. The clock value is not reported by CurrentSeed.
+func (x *Xoshiro256StarStar) seedClock() {
+	x.Seed(uint64(time.Now().UnixNano()))
+	x.seeded = false
+}
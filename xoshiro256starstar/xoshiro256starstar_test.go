@@ -0,0 +1,798 @@
+package xoshiro256starstar
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/bits"
+	"regexp"
+	"testing"
+)
+
+func TestSeedN(t *testing.T) {
+	state := func(seeds ...uint64) string {
+		x := &Xoshiro256StarStar{}
+		x.SeedN(seeds...)
+		b, err := x.Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+	if state(7, 8, 9) != state(7, 8, 9) {
+		t.Error("SeedN with the same values produced different states")
+	}
+	tuples := [][]uint64{{7, 8, 9}, {7, 8, 10}, {9, 8, 7}, {7, 8}, {7, 8, 9, 0}, {1}}
+	seen := make(map[string]int)
+	for i, tuple := range tuples {
+		s := state(tuple...)
+		if j, ok := seen[s]; ok {
+			t.Errorf("SeedN(%v) and SeedN(%v) produced the same state", tuples[j], tuple)
+		}
+		seen[s] = i
+	}
+}
+
+func TestFloat64Closed(t *testing.T) {
+	x := &Xoshiro256StarStar{}
+	setNext(x, 0)
+	if got := x.Float64Closed(); got != 0 {
+		t.Errorf("Float64Closed after a zero output = %v, want 0", got)
+	}
+	setNext(x, ^uint64(0))
+	if got := x.Float64Closed(); got != 1 {
+		t.Errorf("Float64Closed after a maximal output = %v, want 1", got)
+	}
+	x.Seed(1)
+	for i := 0; i < 100000; i++ {
+		if v := x.Float64Closed(); v < 0 || v > 1 {
+			t.Fatalf("Float64Closed() = %v, outside [0, 1]", v)
+		}
+	}
+}
+
+// setNext sets the state of x so that its next call to Uint64 returns v.
+func setNext(x *Xoshiro256StarStar, v uint64) {
+	x.state[1] = bits.RotateLeft64(v*inverse(9), -7) * inverse(5)
+}
+
+// inverse returns the multiplicative inverse of the odd number a modulo 2^64.
+func inverse(a uint64) uint64 {
+	inv := a
+	for i := 0; i < 5; i++ {
+		inv *= 2 - a*inv
+	}
+	return inv
+}
+
+func TestNewSafeFrom(t *testing.T) {
+	x := &Xoshiro256StarStar{}
+	x.Seed(1)
+	x.Uint64()
+	want := *x
+	safe := NewSafeFrom(x)
+	for i := 0; i < 100; i++ {
+		if got, w := safe.Uint64(), want.Uint64(); got != w {
+			t.Fatalf("output %d = %#x, want %#x", i, got, w)
+		}
+	}
+}
+
+func TestFloat64Bits(t *testing.T) {
+	x := &Xoshiro256StarStar{}
+	x.Seed(1)
+	const n = 80000
+	counts := make(map[float64]int)
+	for i := 0; i < n; i++ {
+		counts[x.Float64Bits(3)]++
+	}
+	if len(counts) != 8 {
+		t.Fatalf("Float64Bits(3) produced %d distinct values, want 8", len(counts))
+	}
+	for v, c := range counts {
+		if v < 0 || v >= 1 || v*8 != math.Trunc(v*8) {
+			t.Errorf("Float64Bits(3) produced %v, not a multiple of 1/8 in [0, 1)", v)
+		}
+		if math.Abs(float64(c)-n/8) > 0.05*n/8 {
+			t.Errorf("value %v drawn %d times, want about %d", v, c, n/8)
+		}
+	}
+	for _, mantissaBits := range []int{1, 40, 53} {
+		scale := math.Ldexp(1, mantissaBits)
+		for i := 0; i < 1000; i++ {
+			if v := x.Float64Bits(mantissaBits); v < 0 || v >= 1 || v*scale != math.Trunc(v*scale) {
+				t.Fatalf("Float64Bits(%d) = %v", mantissaBits, v)
+			}
+		}
+	}
+	for _, mantissaBits := range []int{0, 54} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Float64Bits(%d) did not panic", mantissaBits)
+				}
+			}()
+			x.Float64Bits(mantissaBits)
+		}()
+	}
+}
+
+func TestUint64Range(t *testing.T) {
+	x := &Xoshiro256StarStar{}
+	x.Seed(1)
+	const max = ^uint64(0)
+	for i := 0; i < 100; i++ {
+		if v := x.Uint64Range(max-1, max); v != max-1 {
+			t.Fatalf("Uint64Range(max-1, max) = %d", v)
+		}
+		if v := x.Uint64Range(0, 1); v != 0 {
+			t.Fatalf("Uint64Range(0, 1) = %d", v)
+		}
+	}
+	counts := make(map[uint64]int)
+	for i := 0; i < 30000; i++ {
+		counts[x.Uint64Range(5, 8)]++
+	}
+	for v := uint64(5); v < 8; v++ {
+		if c := counts[v]; c < 9500 || c > 10500 {
+			t.Errorf("Uint64Range(5, 8) returned %d %d times, want about 10000", v, c)
+		}
+	}
+	if len(counts) != 3 {
+		t.Errorf("Uint64Range(5, 8) returned values outside [5, 8): %v", counts)
+	}
+	// A range covering three quarters of the domain: values must stay inside it and
+	// spread evenly across it.
+	lo, hi := uint64(1)<<62, max
+	upper := 0
+	const n = 40000
+	for i := 0; i < n; i++ {
+		v := x.Uint64Range(lo, hi)
+		if v < lo || v >= hi {
+			t.Fatalf("Uint64Range(%d, %d) = %d", lo, hi, v)
+		}
+		if v >= lo+(hi-lo)/2 {
+			upper++
+		}
+	}
+	if frac := float64(upper) / n; frac < 0.48 || frac > 0.52 {
+		t.Errorf("upper half of the range drawn %v of the time, want about 0.5", frac)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Uint64Range(3, 3) did not panic")
+		}
+	}()
+	x.Uint64Range(3, 3)
+}
+
+func TestChecksum(t *testing.T) {
+	a, b := &Xoshiro256StarStar{}, &Xoshiro256StarStar{}
+	a.Seed(1)
+	b.Seed(1)
+	if a.Checksum() != b.Checksum() {
+		t.Fatal("identical states have different checksums")
+	}
+	state, err := a.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for bit := 0; bit < len(state)*8; bit++ {
+		flipped := append([]byte(nil), state...)
+		flipped[bit/8] ^= 1 << (bit % 8)
+		if err := b.Unmarshal(flipped); err != nil {
+			t.Fatal(err)
+		}
+		if b.Checksum() == a.Checksum() {
+			t.Errorf("flipping state bit %d did not change the checksum", bit)
+		}
+	}
+}
+
+func TestMarshalJSONCanonical(t *testing.T) {
+	x := &Xoshiro256StarStar{}
+	x.Seed(1)
+	first, err := x.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := x.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(first, second) {
+		t.Fatalf("MarshalJSON is not deterministic: %s != %s", first, second)
+	}
+	// encoding/json writes maps compactly with sorted keys, so canonical output survives
+	// a decode and re-encode unchanged.
+	var generic map[string]interface{}
+	if err := json.Unmarshal(first, &generic); err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := json.Marshal(generic); !bytes.Equal(first, again) {
+		t.Errorf("MarshalJSON output %s is not canonical, want %s", first, again)
+	}
+	for _, word := range regexp.MustCompile(`"([^"]*)"`).FindAllStringSubmatch(string(first), -1) {
+		if _, isKey := generic[word[1]]; isKey {
+			continue
+		}
+		if !regexp.MustCompile(`^([0-9a-f]{16}){1,2}$`).MatchString(word[1]) {
+			t.Errorf("state word %q is not fixed-width lowercase hex", word[1])
+		}
+	}
+
+	restored := &Xoshiro256StarStar{}
+	if err := restored.UnmarshalJSON(first); err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := restored.MarshalJSON(); !bytes.Equal(first, again) {
+		t.Errorf("round trip changed the JSON: %s != %s", first, again)
+	}
+	for i := 0; i < 10; i++ {
+		if got, want := restored.Uint64(), x.Uint64(); got != want {
+			t.Fatalf("output %d after round trip = %#x, want %#x", i, got, want)
+		}
+	}
+}
+
+func TestIntLargeBounds(t *testing.T) {
+	x := &Xoshiro256StarStar{}
+	x.Seed(1)
+	for _, n := range []int{math.MaxInt, math.MaxInt / 2, math.MaxInt/2 + 1} {
+		for i := 0; i < 10000; i++ {
+			if v := x.Int(n); v < 0 || v >= n {
+				t.Fatalf("Int(%d) = %d, out of range", n, v)
+			}
+		}
+	}
+	// With n three quarters of the way to the top of the range, reducing a draw modulo n
+	// without rejection would return values from the lowest third of [0, n) half of the time.
+	n := math.MaxInt / 4 * 3
+	const draws = 60000
+	counts := [3]int{}
+	for i := 0; i < draws; i++ {
+		counts[x.Int(n)/(n/3+1)]++
+	}
+	for i, c := range counts {
+		if math.Abs(float64(c)-draws/3) > 0.03*draws/3 {
+			t.Errorf("third %d of [0, %d) drawn %d times, want about %d", i, n, c, draws/3)
+		}
+	}
+	top := 0
+	for i := 0; i < draws; i++ {
+		if x.Int(math.MaxInt) >= math.MaxInt/2 {
+			top++
+		}
+	}
+	if frac := float64(top) / draws; math.Abs(frac-0.5) > 0.01 {
+		t.Errorf("upper half of [0, MaxInt) drawn %v of the time, want about 0.5", frac)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	x := &Xoshiro256StarStar{}
+	x.Seed(1)
+	for i := 0; i < 1000; i++ {
+		asUint64, asFloat64 := *x, *x
+		v, f := x.Split()
+		if want := asUint64.Uint64(); v != want {
+			t.Fatalf("Split integer = %#x, want the next Uint64 %#x", v, want)
+		}
+		if want := asFloat64.Float64(); f != want {
+			t.Fatalf("Split float = %v, want the next Float64 %v", f, want)
+		}
+		if *x != asUint64 {
+			t.Fatal("Split consumed a different number of draws than Uint64")
+		}
+	}
+}
+
+func TestNewWithWarmupZero(t *testing.T) {
+	// The first outputs of the reference implementation after seeding with SplitMix64(42),
+	// as recorded in testdata/golden/xoshiro256starstar.txt.
+	want := []uint64{0x15780b2e0c2ec716, 0x6104d9866d113a7e, 0xae17533239e499a1}
+	x := NewWithWarmup(42, 0)
+	bare := &Xoshiro256StarStar{}
+	bare.Seed(42)
+	for i, w := range want {
+		if got := x.Uint64(); got != w {
+			t.Fatalf("NewWithWarmup(42, 0) output %d = %#x, want %#x", i, got, w)
+		}
+		if got := bare.Uint64(); got != w {
+			t.Fatalf("Seed(42) output %d = %#x, want %#x", i, got, w)
+		}
+	}
+}
+
+func TestNewWithWarmupDiscards(t *testing.T) {
+	x := NewWithWarmup(42, DefaultWarmup)
+	bare := NewWithWarmup(42, 0)
+	for i := 0; i < DefaultWarmup; i++ {
+		bare.Uint64()
+	}
+	for i := 0; i < 10; i++ {
+		if got, want := x.Uint64(), bare.Uint64(); got != want {
+			t.Fatalf("output %d = %#x, want %#x", i, got, want)
+		}
+	}
+}
+
+func TestFillIntN(t *testing.T) {
+	x := &Xoshiro256StarStar{}
+	x.Seed(1)
+	ref := *x
+	const n = 6
+	dst := make([]int, 60000)
+	x.FillIntN(dst, n)
+	// Reference extraction: 16-bit chunks from the least significant end of each draw,
+	// rejecting chunks at or above the largest multiple of n.
+	limit := uint64(1<<16 - (1<<16)%n)
+	var want []int
+	for len(want) < len(dst) {
+		v := ref.Uint64()
+		for j := 0; j < 4 && len(want) < len(dst); j++ {
+			if c := v & 0xffff; c < limit {
+				want = append(want, int(c%n))
+			}
+			v >>= 16
+		}
+	}
+	counts := make([]int, n)
+	for i, v := range dst {
+		if v != want[i] {
+			t.Fatalf("element %d = %d, want %d", i, v, want[i])
+		}
+		counts[v]++
+	}
+	for v, c := range counts {
+		if math.Abs(float64(c)-float64(len(dst))/n) > 0.03*float64(len(dst))/n {
+			t.Errorf("value %d drawn %d times, want about %d", v, c, len(dst)/n)
+		}
+	}
+
+	// Above 2^16 every element is a separate call to Int.
+	x.Seed(2)
+	ref = *x
+	large := make([]int, 100)
+	x.FillIntN(large, 1<<20)
+	for i, v := range large {
+		if w := ref.Int(1 << 20); v != w {
+			t.Fatalf("large bound: element %d = %d, want Int result %d", i, v, w)
+		}
+	}
+	safe := NewSafe()
+	safe.Seed(1)
+	got := make([]int, len(dst))
+	safe.FillIntN(got, n)
+	for i := range got {
+		if got[i] != dst[i] {
+			t.Fatalf("safe variant element %d = %d, want %d", i, got[i], dst[i])
+		}
+	}
+}
+
+func TestShuffleRange(t *testing.T) {
+	x := &Xoshiro256StarStar{}
+	x.Seed(1)
+	s := make([]int, 20)
+	for i := range s {
+		s[i] = i
+	}
+	moved := false
+	for trial := 0; trial < 100; trial++ {
+		x.ShuffleRange(5, 15, func(a, b int) { s[a], s[b] = s[b], s[a] })
+		seen := make(map[int]bool)
+		for i, v := range s {
+			if i < 5 || i >= 15 {
+				if v != i {
+					t.Fatalf("element %d outside [5, 15) changed to %d", i, v)
+				}
+				continue
+			}
+			if v < 5 || v >= 15 || seen[v] {
+				t.Fatalf("elements in [5, 15) are not a permutation: %v", s)
+			}
+			seen[v] = true
+			if v != i {
+				moved = true
+			}
+		}
+	}
+	if !moved {
+		t.Error("ShuffleRange never moved an element")
+	}
+	x.ShuffleRange(3, 3, func(a, b int) { t.Fatal("swap called for an empty range") })
+	for _, r := range [][2]int{{-1, 2}, {4, 3}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("ShuffleRange(%d, %d) did not panic", r[0], r[1])
+				}
+			}()
+			x.ShuffleRange(r[0], r[1], func(a, b int) {})
+		}()
+	}
+}
+
+func TestStir(t *testing.T) {
+	a, b := &Xoshiro256StarStar{}, &Xoshiro256StarStar{}
+	a.Seed(1)
+	b.Seed(1)
+	before, _ := a.Marshal()
+	a.Stir()
+	b.Stir()
+	stateA, _ := a.Marshal()
+	stateB, _ := b.Marshal()
+	if !bytes.Equal(stateA, stateB) {
+		t.Fatal("Stir produced different states from the same state")
+	}
+	if bytes.Equal(stateA, before) {
+		t.Fatal("Stir did not change the state")
+	}
+	for i := 0; i < 100; i++ {
+		if a.Uint64() != b.Uint64() {
+			t.Fatalf("output %d differs after Stir from the same state", i)
+		}
+	}
+
+	// Even an all-zero state, which is degenerate for some generators, must be stirred
+	// into one that produces varied output.
+	z := &Xoshiro256StarStar{}
+	if err := z.Unmarshal(make([]byte, len(before))); err != nil {
+		t.Fatal(err)
+	}
+	z.Stir()
+	seen := make(map[uint64]bool)
+	for i := 0; i < 100; i++ {
+		seen[z.Uint64()] = true
+	}
+	if len(seen) < 99 {
+		t.Errorf("stirred zero state produced only %d distinct values in 100 draws", len(seen))
+	}
+}
+
+func TestFullInt64(t *testing.T) {
+	a, b := &Xoshiro256StarStar{}, &Xoshiro256StarStar{}
+	a.Seed(1)
+	b.Seed(1)
+	negative := 0
+	for i := 0; i < 1000; i++ {
+		v, u := a.FullInt64(), b.Uint64()
+		if uint64(v) != u || int64(u) != v {
+			t.Fatalf("FullInt64() = %d, want the two's complement of Uint64() = %#x", v, u)
+		}
+		if (v < 0) != (u>>63 == 1) {
+			t.Fatalf("FullInt64() = %d for Uint64() = %#x: sign does not match the high bit", v, u)
+		}
+		if v < 0 {
+			negative++
+		}
+	}
+	if negative < 400 || negative > 600 {
+		t.Errorf("%d of 1000 values were negative, want about half", negative)
+	}
+
+	// The state saved by Marshal restores the same FullInt64 stream.
+	state, err := a.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := a.FullInt64()
+	c := &Xoshiro256StarStar{}
+	if err := c.Unmarshal(state); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.FullInt64(); got != want {
+		t.Errorf("FullInt64() after Unmarshal = %d, want %d", got, want)
+	}
+}
+
+func TestDerive(t *testing.T) {
+	parent := &Xoshiro256StarStar{}
+	parent.Seed(1)
+	trees, rivers := parent.Derive("trees"), parent.Derive("rivers")
+	for i := 0; i < 10; i++ {
+		parent.Uint64()
+	}
+	// The seed, not the position in the stream, selects the sub-generator.
+	again := parent.Derive("trees")
+	same := 0
+	for i := 0; i < 100; i++ {
+		v := trees.Uint64()
+		if again.Uint64() != v {
+			t.Fatalf("output %d of Derive(\"trees\") changed after the parent advanced", i)
+		}
+		if rivers.Uint64() == v {
+			same++
+		}
+	}
+	if same > 1 {
+		t.Errorf("Derive(\"trees\") and Derive(\"rivers\") agree on %d of 100 outputs", same)
+	}
+
+	// Without a seed, the current state selects the sub-generator.
+	state, err := parent.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, b := &Xoshiro256StarStar{}, &Xoshiro256StarStar{}
+	if err := a.Unmarshal(state); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Unmarshal(state); err != nil {
+		t.Fatal(err)
+	}
+	da, db := a.Derive("trees"), b.Derive("trees")
+	for i := 0; i < 100; i++ {
+		if da.Uint64() != db.Uint64() {
+			t.Fatalf("output %d of Derive differs between generators with the same state", i)
+		}
+	}
+	if da.Uint64() == parent.Derive("trees").Uint64() {
+		t.Error("Derive after Unmarshal matches Derive from the seed")
+	}
+}
+
+func TestNewSnapshot(t *testing.T) {
+	x, state := NewSnapshot(1)
+	want := make([]uint64, 100)
+	for i := range want {
+		want[i] = x.Uint64()
+	}
+	if err := x.Unmarshal(state); err != nil {
+		t.Fatal(err)
+	}
+	for i, w := range want {
+		if got := x.Uint64(); got != w {
+			t.Fatalf("output %d after restoring the snapshot = %#x, want %#x", i, got, w)
+		}
+	}
+	seeded := &Xoshiro256StarStar{}
+	seeded.Seed(1)
+	snap, _ := NewSnapshot(1)
+	for i := 0; i < 100; i++ {
+		if seeded.Uint64() != snap.Uint64() {
+			t.Fatalf("NewSnapshot(1) differs from Seed(1) at output %d", i)
+		}
+	}
+}
+
+// safeCalls returns a call of every method of s.
+func safeCalls(s *SafeXoshiro256StarStar) []func() {
+	state, _ := s.Marshal()
+	stateJSON, _ := s.MarshalJSON()
+	ints := make([]int, 8)
+	var arr [8]int
+	swap := func(a, b int) { arr[a], arr[b] = arr[b], arr[a] }
+	return []func(){
+		func() { s.State() },
+		func() { s.Checksum() },
+		func() { s.Reset() },
+		func() { s.Marshal() },
+		func() { s.Unmarshal(state) },
+		func() { s.MarshalJSON() },
+		func() { s.UnmarshalJSON(stateJSON) },
+		func() { s.Seed(2) },
+		func() { s.SeedN(3, 4) },
+		func() { s.Jump() },
+		func() { s.LongJump() },
+		func() { s.Uint64() },
+		func() { s.Int64() },
+		func() { s.Uint32() },
+		func() { s.Int32() },
+		func() { s.Float32() },
+		func() { s.Int(10) },
+		func() { s.Uint64Range(5, 10) },
+		func() { s.Float64() },
+		func() { s.Float64Closed() },
+		func() { s.Float64Bits(20) },
+		func() { s.Split() },
+		func() { s.FillIntN(ints, 10) },
+		func() { s.ShuffleRange(2, 6, swap) },
+		func() { s.Stir() },
+		func() { s.FullInt64() },
+		func() { s.Derive("child") },
+		func() { s.PermInto(ints) },
+		func() { s.CurrentSeed() },
+		func() { s.IntExcept(10, 3) },
+		func() { s.Shuffle(len(arr), swap) },
+	}
+}
+
+// TestSafeConcurrentUse calls every method of a SafeXoshiro256StarStar while another goroutine calls
+// Uint64. Run it with -race: a method that does not lock the generator has no
+// synchronization with that goroutine, so the race detector reports it.
+func TestSafeConcurrentUse(t *testing.T) {
+	s := &SafeXoshiro256StarStar{}
+	s.Seed(1)
+	for _, call := range safeCalls(s) {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < 100; i++ {
+				s.Uint64()
+			}
+		}()
+		for i := 0; i < 100; i++ {
+			call()
+		}
+		<-done
+	}
+}
+
+func TestPermInto(t *testing.T) {
+	x := &Xoshiro256StarStar{}
+	x.Seed(1)
+	dst := make([]int, 50)
+	for trial := 0; trial < 10; trial++ {
+		x.PermInto(dst)
+		seen := make([]bool, len(dst))
+		for _, v := range dst {
+			if v < 0 || v >= len(dst) || seen[v] {
+				t.Fatalf("PermInto produced %v, not a permutation", dst)
+			}
+			seen[v] = true
+		}
+	}
+	if allocs := testing.AllocsPerRun(100, func() { x.PermInto(dst) }); allocs != 0 {
+		t.Errorf("PermInto allocated %v times per call, want 0", allocs)
+	}
+
+	// PermInto is the inside-out Fisher–Yates shuffle driven by Int.
+	a, b := &Xoshiro256StarStar{}, &Xoshiro256StarStar{}
+	a.Seed(7)
+	b.Seed(7)
+	a.PermInto(dst)
+	want := make([]int, len(dst))
+	for i := 1; i < len(want); i++ {
+		j := b.Int(i + 1)
+		want[i] = want[j]
+		want[j] = i
+	}
+	for i := range want {
+		if dst[i] != want[i] {
+			t.Fatalf("PermInto = %v, want %v", dst, want)
+		}
+	}
+	x.PermInto(nil)
+}
+
+func TestCurrentSeed(t *testing.T) {
+	x := &Xoshiro256StarStar{}
+	if _, ok := x.CurrentSeed(); ok {
+		t.Error("CurrentSeed reports a seed for a zero generator")
+	}
+	x.Seed(12345)
+	if seed, ok := x.CurrentSeed(); !ok || seed != 12345 {
+		t.Errorf("CurrentSeed() after Seed(12345) = %d, %v", seed, ok)
+	}
+	x.Uint64()
+	if seed, ok := x.CurrentSeed(); !ok || seed != 12345 {
+		t.Errorf("CurrentSeed() after drawing a value = %d, %v; want 12345, true", seed, ok)
+	}
+	state, _ := x.Marshal()
+	stateJSON, _ := x.MarshalJSON()
+	for name, reseed := range map[string]func(){
+		"Seed(0)":       func() { x.Seed(0) },
+		"SeedN":         func() { x.SeedN(1, 2) },
+		"Unmarshal":     func() { x.Unmarshal(state) },
+		"UnmarshalJSON": func() { x.UnmarshalJSON(stateJSON) },
+	} {
+		x.Seed(99)
+		reseed()
+		if seed, ok := x.CurrentSeed(); ok {
+			t.Errorf("CurrentSeed() after %s = %d, true; want false", name, seed)
+		}
+	}
+	if _, ok := New().CurrentSeed(); ok {
+		t.Error("CurrentSeed reports a seed for a clock-seeded generator")
+	}
+}
+
+func TestIntExcept(t *testing.T) {
+	x := &Xoshiro256StarStar{}
+	x.Seed(1)
+	const n, exclude, draws = 10, 3, 100000
+	var counts [n]int
+	for i := 0; i < draws; i++ {
+		v := x.IntExcept(n, exclude)
+		if v < 0 || v >= n {
+			t.Fatalf("IntExcept(%d, %d) = %d, out of range", n, exclude, v)
+		}
+		counts[v]++
+	}
+	if counts[exclude] != 0 {
+		t.Fatalf("IntExcept returned the excluded value %d times", counts[exclude])
+	}
+	want := float64(draws) / (n - 1)
+	for v, c := range counts {
+		if v != exclude && math.Abs(float64(c)-want) > 5*math.Sqrt(want) {
+			t.Errorf("value %d drawn %d times, want about %v", v, c, want)
+		}
+	}
+	for _, e := range []int{0, 1} {
+		if v := x.IntExcept(2, e); v != 1-e {
+			t.Errorf("IntExcept(2, %d) = %d, want %d", e, v, 1-e)
+		}
+	}
+
+	for _, c := range [][2]int{{1, 0}, {0, 0}, {5, -1}, {5, 5}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("IntExcept(%d, %d) did not panic", c[0], c[1])
+				}
+			}()
+			x.IntExcept(c[0], c[1])
+		}()
+	}
+	s := &SafeXoshiro256StarStar{}
+	s.Seed(1)
+	if v := s.IntExcept(2, 0); v != 1 {
+		t.Errorf("SafeXoshiro256StarStar.IntExcept(2, 0) = %d, want 1", v)
+	}
+}
+
+func TestShuffleGolden(t *testing.T) {
+	// Shuffling 0..9 with a generator seeded with 42 must always give this order. Changing
+	// it breaks reproducibility of every shuffle.
+	want := []int{7, 3, 8, 9, 5, 6, 4, 1, 0, 2}
+	s := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	x := &Xoshiro256StarStar{}
+	x.Seed(42)
+	x.Shuffle(len(s), func(a, b int) { s[a], s[b] = s[b], s[a] })
+	if fmt.Sprint(s) != fmt.Sprint(want) {
+		t.Errorf("Shuffle with seed 42 = %v, want %v", s, want)
+	}
+
+	// The documented draw sequence: for i from n-1 down to 1, swap(i, Int(i+1)).
+	ref := &Xoshiro256StarStar{}
+	ref.Seed(42)
+	x.Seed(42)
+	var swaps [][2]int
+	x.Shuffle(10, func(a, b int) { swaps = append(swaps, [2]int{a, b}) })
+	if len(swaps) != 9 {
+		t.Fatalf("Shuffle(10) made %d swaps, want 9", len(swaps))
+	}
+	for k, i := 0, 9; i > 0; k, i = k+1, i-1 {
+		if sw := [2]int{i, ref.Int(i + 1)}; swaps[k] != sw {
+			t.Fatalf("swap %d = %v, want %v", k, swaps[k], sw)
+		}
+	}
+
+	safe := &SafeXoshiro256StarStar{}
+	safe.Seed(42)
+	s = []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	safe.Shuffle(len(s), func(a, b int) { s[a], s[b] = s[b], s[a] })
+	if fmt.Sprint(s) != fmt.Sprint(want) {
+		t.Errorf("SafeXoshiro256StarStar.Shuffle with seed 42 = %v, want %v", s, want)
+	}
+}
+
+func TestIntRejection(t *testing.T) {
+	// Int(n) discards exactly the draws below 2^64 mod n and reduces the rest modulo n, so
+	// draws in the upper half of the range are kept like any other.
+	for _, n := range []int{3, 1000, math.MaxInt / 8 * 3} {
+		x, ref := &Xoshiro256StarStar{}, &Xoshiro256StarStar{}
+		x.Seed(1)
+		ref.Seed(1)
+		threshold := -uint64(n) % uint64(n)
+		high := 0
+		for i := 0; i < 1000; i++ {
+			v := ref.Uint64()
+			for v < threshold {
+				v = ref.Uint64()
+			}
+			if v >= 1<<63 {
+				high++
+			}
+			if got, want := x.Int(n), int(v%uint64(n)); got != want {
+				t.Fatalf("Int(%d) call %d = %d, want %d", n, i, got, want)
+			}
+		}
+		if high < 300 {
+			t.Errorf("Int(%d) kept only %d draws of 1000 at or above 2^63", n, high)
+		}
+	}
+}